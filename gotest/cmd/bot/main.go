@@ -1,13 +1,34 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Часовые пояса пользователей доступны и без системной базы tzdata
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"telegram-bot/internal/admins"
+	"telegram-bot/internal/broadcast"
 	"telegram-bot/internal/config"
+	"telegram-bot/internal/database"
+	"telegram-bot/internal/domain"
 	"telegram-bot/internal/handler"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/menu"
 	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/moderation"
+	"telegram-bot/internal/privacy"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/session"
+	"telegram-bot/internal/settings"
+	"telegram-bot/internal/stats"
 )
 
 func main() {
@@ -26,20 +47,161 @@ func main() {
 	bot.Debug = cfg.Bot.Debug
 	log.Printf("Авторизован как %s", bot.Self.UserName)
 
+	// Открываем хранилище данных: память, PostgreSQL или SQLite (DB_DRIVER)
+	store, err := openStore(cfg.Database)
+	if err != nil {
+		log.Fatal("Ошибка подключения к БД:", err)
+	}
+	defer store.Close()
+
+	userTracker := middleware.NewUserTracker(store.Users)
+	chatTracker := middleware.NewChatTracker(store.Chats)
+
+	// Журнал взаимодействий: что присылали пользователи и как бот это обработал
+	var interactionLog *middleware.InteractionLog
+	if cfg.Logging.Interactions {
+		interactionLog = middleware.NewInteractionLog(store.Interactions, cfg.Logging.InteractionsRetention)
+		go interactionLog.Run()
+	}
+
+	// Настройки пользователей: язык, уведомления, часовой пояс, тихие часы
+	settingsService := settings.NewService(store.Settings, domain.UserSettings{
+		NotificationsEnabled: true,
+		TimeZone:             cfg.Bot.DefaultTimeZone,
+	})
+
+	// Загружаем каталоги сообщений; выбранный пользователем язык хранится в его настройках
+	bundle, err := i18n.LoadDir(cfg.I18n.Dir, cfg.I18n.DefaultLanguage)
+	if err != nil {
+		log.Fatal("Ошибка загрузки переводов:", err)
+	}
+	loc := i18n.NewLocalizer(bundle, settingsService)
+	importLanguages(settingsService, store, cfg.I18n.LanguagesFile)
+	log.Printf("Загружены языки: %v", bundle.Languages())
+	watchTranslations(bundle, cfg.I18n)
+
+	// Администраторы: владельцы из ADMIN_IDS и назначенные ими через бота
+	adminService := admins.NewService(store, cfg.Bot.AdminIDs)
+	if err := adminService.Load(context.Background()); err != nil {
+		log.Fatal("Ошибка загрузки администраторов:", err)
+	}
+
+	// Блокировки и запреты писать: обновления ограниченных пользователей не доходят до обработчиков
+	moderationService := moderation.NewService(store)
+	if err := moderationService.Load(context.Background()); err != nil {
+		log.Fatal("Ошибка загрузки ограничений пользователей:", err)
+	}
+	go moderationService.Run()
+	restrictionGuard := middleware.NewRestrictionGuard(moderationService, adminService, loc)
+
+	// Хранилище сессий: активные сценарии пользователей
+	sessions := session.NewStore(30 * time.Minute)
+
+	// Создаём обработчики команд
+	infoHandler := handler.NewInfoHandler(loc)
+	helpHandler := handler.NewHelpHandler(loc)
+	settingsHandler := handler.NewSettingsHandler(loc, settingsService)
+	aboutHandler := handler.NewAboutHandler(loc)
+	cancelHandler := handler.NewCancelHandler(sessions, loc)
+
+	// Главное меню на обычной клавиатуре: каждая кнопка связана со своим обработчиком
+	mainMenu := handler.NewMainMenu(bundle, infoHandler, settingsHandler, helpHandler, aboutHandler, cancelHandler)
+
+	// Подтверждения действий кнопками "Да"/"Нет"; выгрузка и удаление данных по запросу пользователя
+	confirmManager := handler.NewConfirmManager(loc)
+	privacyService := privacy.NewService(store, sessions, settingsService)
+	// Права администратора и причина ограничения держатся в памяти — после удаления данных их тоже забываем
+	privacyService.OnErase(adminService.Forget)
+	privacyService.OnErase(moderationService.Forget)
+	if interactionLog != nil {
+		// Записи об удалённом пользователе, ждущие в очереди журнала, не должны пережить удаление
+		privacyService.OnErase(interactionLog.Forget)
+	}
+
+	// Рассылки администраторов; незавершённые до перезапуска рассылки продолжаются
+	broadcastService := broadcast.NewService(bot, store, settingsService, handler.NewBroadcastReporter(loc), cfg.Broadcast)
+	if err := broadcastService.Resume(context.Background()); err != nil {
+		log.Printf("Ошибка возобновления рассылок: %v", err)
+	}
+	broadcastHandler := handler.NewBroadcastHandler(broadcastService, settingsService, sessions, confirmManager, adminService, loc)
+
+	// Рассылки по расписанию; пропущенные, пока бот не работал, отправляются сразу после запуска
+	scheduler := broadcast.NewScheduler(broadcastService, store.Schedules)
+	go scheduler.Run()
+
+	// Календарь и выбор времени
+	datePicker := handler.NewDatePicker(settingsService, loc)
+
+	// Списки с множественным выбором
+	checklist := handler.NewChecklist(loc)
+	scheduleHandler := handler.NewScheduleHandler(scheduler, broadcastService, settingsService, sessions, confirmManager, datePicker, adminService, loc)
+
 	// Создаём диспетчер обработчиков
-	dispatcher := handler.NewDispatcher()
+	dispatcher := handler.NewDispatcher(loc)
 
 	// Регистрируем обработчики команд
-	dispatcher.Register(handler.NewStartHandler())
-	dispatcher.Register(handler.NewHelpHandler())
-	dispatcher.Register(handler.NewInfoHandler())
-	dispatcher.Register(handler.NewAdminHandler(cfg.Bot.AdminIDs))
+	dispatcher.Register(handler.NewStartHandler(loc))
+	dispatcher.Register(helpHandler)
+	dispatcher.Register(infoHandler)
+	dispatcher.Register(handler.NewAdminHandler(adminService, loc))
+	chatsHandler := handler.NewChatsHandler(store.Chats, adminService, loc)
+	dispatcher.Register(chatsHandler)
+	dispatcher.Register(handler.NewRestrictHandler(domain.RestrictionBan, moderationService, store.Users, settingsService, adminService, loc))
+	dispatcher.Register(handler.NewRestrictHandler(domain.RestrictionMute, moderationService, store.Users, settingsService, adminService, loc))
+	dispatcher.Register(handler.NewUnbanHandler(moderationService, store.Users, adminService, loc))
+	dispatcher.Register(handler.NewAdminsHandler(adminService, store.Users, settingsService, loc))
+	dispatcher.Register(handler.NewGrantHandler(adminService, store.Users, loc))
+	dispatcher.Register(handler.NewRevokeHandler(adminService, store.Users, loc))
+	dispatcher.Register(handler.NewLogHandler(store.Interactions, settingsService, adminService, loc))
+	statsHandler := handler.NewStatsHandler(stats.NewService(store.Users, store.Interactions), settingsService, adminService, loc)
+	dispatcher.Register(statsHandler)
+	dispatcher.Register(broadcastHandler)
+	dispatcher.Register(scheduleHandler)
+	dispatcher.Register(handler.NewSegmentHandler(store.Segments, broadcastService, settingsService, checklist, adminService, loc))
+	dispatcher.Register(settingsHandler)
+	dispatcher.Register(aboutHandler)
+	dispatcher.Register(cancelHandler)
+	deleteMeHandler := handler.NewDeleteMeHandler(confirmManager, privacyService, loc)
+	dispatcher.Register(deleteMeHandler)
+	dispatcher.Register(handler.NewMyDataHandler(privacyService, cfg.Bot.ExportCooldown, loc))
+	dispatcher.Register(handler.NewKeyboardHandler(mainMenu, loc))
+
+	// Загружаем инлайн-меню из файла (если он есть)
+	menuHandler, err := newMenuHandler(cfg.Bot, dispatcher, adminService, loc)
+	if err != nil {
+		log.Fatal("Ошибка загрузки меню:", err)
+	}
+	if menuHandler != nil {
+		dispatcher.Register(menuHandler)
+	}
 
 	// Создаём обработчик обычных сообщений
-	messageHandler := handler.NewMessageHandler()
+	messageHandler := handler.NewMessageHandler(mainMenu, sessions, loc)
+	messageHandler.RegisterFlow(broadcastHandler)
+	messageHandler.RegisterFlow(scheduleHandler)
 
 	// Создаём обработчик callback-запросов (для инлайн-кнопок)
-	callbackHandler := handler.NewCallbackHandler()
+	callbackHandler := handler.NewCallbackHandler(deleteMeHandler, loc)
+
+	// Кнопки экрана настроек и навигация по списку чатов
+	callbackHandler.Register(settingsHandler)
+	callbackHandler.Register(chatsHandler)
+	callbackHandler.Register(statsHandler)
+	callbackHandler.Register(broadcastHandler)
+	callbackHandler.Register(scheduleHandler)
+
+	// Подтверждения действий кнопками "Да"/"Нет"
+	callbackHandler.Register(confirmManager)
+
+	// Календарь и выбор времени
+	callbackHandler.Register(datePicker)
+
+	// Списки с множественным выбором
+	callbackHandler.Register(checklist)
+
+	if menuHandler != nil {
+		callbackHandler.Register(menuHandler)
+	}
 
 	// Настраиваем получение обновлений
 	u := tgbotapi.NewUpdate(0)
@@ -48,7 +210,125 @@ func main() {
 
 	// Обрабатываем обновления
 	for update := range updates {
-		handleUpdate(bot, dispatcher, messageHandler, callbackHandler, update)
+		handleUpdate(bot, dispatcher, messageHandler, callbackHandler, userTracker, chatTracker, restrictionGuard, interactionLog, update)
+	}
+}
+
+// newMenuHandler загружает дерево меню и создаёт обработчик /menu.
+// Если файла меню нет, возвращает nil — бот работает без меню
+func newMenuHandler(cfg config.BotConfig, dispatcher *handler.Dispatcher, admins middleware.AdminChecker, loc *i18n.Localizer) (*handler.MenuHandler, error) {
+	tree, err := menu.Load(cfg.MenuFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Файл меню %s не найден, команда /menu отключена", cfg.MenuFile)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Администраторы видят пункты с ролью admin
+	roles := func(userID int64) []string {
+		if admins.IsAdmin(userID) {
+			return []string{menu.RoleUser, menu.RoleAdmin}
+		}
+		return []string{menu.RoleUser}
+	}
+
+	menuHandler := handler.NewMenuHandler(tree, dispatcher, roles, loc)
+	if err := menuHandler.Validate(); err != nil {
+		return nil, err
+	}
+
+	return menuHandler, nil
+}
+
+// openStore открывает хранилище, выбранное в DB_DRIVER.
+// Если включено DB_AUTO_MIGRATE, к БД применяются новые миграции
+func openStore(cfg config.DatabaseConfig) (*repository.Store, error) {
+	if cfg.Driver == database.DriverMemory {
+		log.Printf("ВНИМАНИЕ: DB_DRIVER=memory — пользователи, настройки, рассылки и расписания хранятся в памяти и будут потеряны при перезапуске. Для постоянного хранения используйте DB_DRIVER=sqlite или postgres")
+		return repository.NewMemoryStore(), nil
+	}
+
+	ctx := context.Background()
+
+	db, err := database.Open(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Driver == database.DriverSQLite {
+		log.Printf("Открыта база SQLite %s", cfg.Path)
+	} else {
+		log.Printf("Подключение к БД %s:%d/%s установлено", cfg.Host, cfg.Port, cfg.Name)
+	}
+
+	if cfg.AutoMigrate {
+		migrator, err := database.NewMigrator(db, cfg.Driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		log.Printf("Применено миграций: %d", count)
+	}
+
+	return repository.NewSQLStore(db), nil
+}
+
+// importLanguages переносит языки из файла прежних версий бота в настройки пользователей.
+// В постоянном хранилище импорт выполняется один раз: после него файл переименовывается.
+// Хранилище в памяти теряет настройки при перезапуске, поэтому файл остаётся
+// и импортируется при каждом запуске, а новый выбор языка сохраняется только до перезапуска
+func importLanguages(settingsService *settings.Service, store *repository.Store, path string) {
+	if path == "" {
+		return
+	}
+
+	count, err := settingsService.ImportLanguages(path, store.Users)
+	if count > 0 {
+		log.Printf("Импортировано языков пользователей из %s: %d", path, count)
+	}
+	if err != nil {
+		// Файл не переименовывается: оставшиеся языки перенесутся при следующем запуске
+		log.Printf("Ошибка импорта языков из %s: %v", path, err)
+		return
+	}
+
+	if store.DB() == nil {
+		if _, err := os.Stat(path); err == nil {
+			log.Printf("Хранилище в памяти: выбор языка не переживёт перезапуск, %s будет импортирован снова. Для сохранения настроек используйте DB_DRIVER=sqlite или postgres", path)
+		}
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+".imported"); err != nil {
+			log.Printf("Ошибка переименования %s: %v", path, err)
+		}
+	}
+}
+
+// watchTranslations перечитывает каталоги переводов по сигналу SIGHUP,
+// а если включено I18N_WATCH — ещё и при изменении файлов
+func watchTranslations(bundle *i18n.Bundle, cfg config.I18nConfig) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			if err := bundle.Reload(); err != nil {
+				log.Printf("Ошибка перезагрузки переводов: %v", err)
+				continue
+			}
+			log.Printf("Переводы перезагружены по сигналу SIGHUP: %v", bundle.Languages())
+		}
+	}()
+
+	if cfg.Watch {
+		go bundle.Watch(cfg.WatchInterval)
+		log.Printf("Отслеживаются изменения переводов в %s", cfg.Dir)
 	}
 }
 
@@ -57,19 +337,65 @@ func handleUpdate(
 	dispatcher *handler.Dispatcher,
 	messageHandler *handler.MessageHandler,
 	callbackHandler *handler.CallbackHandler,
+	userTracker *middleware.UserTracker,
+	chatTracker *middleware.ChatTracker,
+	restrictionGuard *middleware.RestrictionGuard,
+	interactionLog *middleware.InteractionLog,
 	update tgbotapi.Update,
 ) {
+	// Запоминаем изменения в группах и каналах бота, кто бы их ни сделал
+	chatTracker.Track(update)
+
+	started := time.Now()
+	var (
+		name string
+		err  error
+	)
+	if kind, restricted := restrictionGuard.Check(bot, update); restricted {
+		// Обновление ограниченного пользователя не доходит до обработчиков
+		// и не обновляет last_seen_at, поэтому не попадает в /stats
+		name = "restricted:" + kind
+	} else {
+		userTracker.Track(update)
+		name, err = routeUpdate(bot, dispatcher, messageHandler, callbackHandler, update)
+		if err != nil {
+			userTracker.ReportError(update, err)
+		}
+	}
+
+	// Записываем обновление и результат обработки в журнал (если он включён)
+	if interactionLog != nil {
+		interactionLog.Record(update, name, time.Since(started), err)
+	}
+}
+
+// routeUpdate передаёт обновление подходящему обработчику.
+// Возвращает имя обработчика для журнала (пусто — обработчика нет) и его ошибку
+func routeUpdate(
+	bot *tgbotapi.BotAPI,
+	dispatcher *handler.Dispatcher,
+	messageHandler *handler.MessageHandler,
+	callbackHandler *handler.CallbackHandler,
+	update tgbotapi.Update,
+) (string, error) {
 	// Обрабатываем callback-запросы (нажатия на инлайн-кнопки)
 	if update.CallbackQuery != nil {
-		if err := callbackHandler.Handle(bot, update.CallbackQuery); err != nil {
+		prefix, _, _ := strings.Cut(update.CallbackQuery.Data, ":")
+		err := callbackHandler.Handle(bot, update.CallbackQuery)
+		if err != nil {
 			log.Printf("Ошибка обработки callback-запроса: %v", err)
 		}
-		return
+		return "callback:" + prefix, err
+	}
+
+	// Изменения статуса бота в чатах обрабатывают трекеры
+	if update.MyChatMember != nil {
+		return "tracker", nil
 	}
 
 	// Обрабатываем сообщения
 	if update.Message == nil {
-		return
+		return "", nil
 	}
 
 	msg := update.Message
@@ -80,14 +406,17 @@ func handleUpdate(
 		if err != nil {
 			log.Printf("Ошибка обработки команды: %v", err)
 		}
-		return
+		return "command:" + msg.Command(), err
 	}
 
-	if msg.Text != "" {
+	if msg.Text != "" || messageHandler.InFlow(msg) {
 		middleware.LogMessage(msg)
 		err := messageHandler.Handle(bot, msg)
 		if err != nil {
 			log.Printf("Ошибка обработки сообщения: %v", err)
 		}
+		return "message", err
 	}
+
+	return "", nil
 }
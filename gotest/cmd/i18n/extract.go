@@ -0,0 +1,153 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"telegram-bot/internal/menu"
+)
+
+// keyPattern — так выглядят ключи каталогов: "section.name"
+var keyPattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)+$`)
+
+// keyArguments — функции, которые принимают ключ не первым аргументом (имя -> номер аргумента)
+var keyArguments = map[string]int{
+	"NewPaginator": 1,
+}
+
+// extractKeys собирает ключи переводов из Go-кода и файлов меню.
+// Возвращает карту: ключ -> место первого использования
+func extractKeys(src, menus string) (map[string]string, error) {
+	keys := make(map[string]string)
+	add := func(key, where string) {
+		if _, exists := keys[key]; !exists && keyPattern.MatchString(key) {
+			keys[key] = where
+		}
+	}
+
+	if err := extractGo(src, add); err != nil {
+		return nil, err
+	}
+	if err := extractMenus(menus, add); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// extractGo ищет в коде вызовы tr.T("ключ"), tr.N("ключ", ...) и поля Key: "ключ"
+func extractGo(src string, add func(key, where string)) error {
+	fset := token.NewFileSet()
+
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name := entry.Name(); path != src && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		literal := func(expr ast.Expr) {
+			lit, ok := expr.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return
+			}
+			if key, err := strconv.Unquote(lit.Value); err == nil {
+				add(key, fset.Position(lit.Pos()).String())
+			}
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				name := ""
+				switch fun := node.Fun.(type) {
+				case *ast.SelectorExpr:
+					name = fun.Sel.Name
+				case *ast.Ident:
+					name = fun.Name
+				}
+
+				if (name == "T" || name == "N") && len(node.Args) > 0 {
+					literal(node.Args[0])
+				}
+				if index, exists := keyArguments[name]; exists && len(node.Args) > index {
+					literal(node.Args[index])
+				}
+			case *ast.KeyValueExpr:
+				if ident, ok := node.Key.(*ast.Ident); ok && strings.EqualFold(ident.Name, "key") {
+					literal(node.Value)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+}
+
+// extractMenus собирает заголовки и тексты из файлов меню, похожие на ключи
+func extractMenus(dir string, add func(key, where string)) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		tree, err := menu.Load(path)
+		if err != nil {
+			return err
+		}
+
+		var walk func(m *menu.Menu)
+		walk = func(m *menu.Menu) {
+			add(m.Title, path)
+			add(m.Text, path)
+			for _, item := range m.Items {
+				add(item.Title, path)
+				if item.Menu != nil {
+					walk(item.Menu)
+				}
+			}
+		}
+		walk(tree.Root)
+	}
+	return nil
+}
+
+// sortedKeys возвращает ключи карты по алфавиту
+func sortedKeys(keys map[string]string) []string {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
@@ -0,0 +1,106 @@
+// Команда i18n помогает держать каталоги переводов в порядке.
+//
+//	go run ./cmd/i18n extract            — ключи, используемые в коде и меню
+//	go run ./cmd/i18n verify [-strict]   — недостающие и лишние ключи в каждом языке
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"telegram-bot/internal/i18n"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	src := flags.String("src", ".", "каталог с исходным кодом")
+	menus := flags.String("menus", "menus", "каталог с файлами меню")
+	dir := flags.String("locales", "locales", "каталог с каталогами переводов")
+	defaultLang := flags.String("default", "ru", "язык по умолчанию")
+	strict := flags.Bool("strict", false, "verify: считать ошибкой и неиспользуемые ключи")
+
+	switch os.Args[1] {
+	case "extract":
+		flags.Parse(os.Args[2:])
+
+		keys, err := extractKeys(*src, *menus)
+		if err != nil {
+			fail(err)
+		}
+		for _, key := range sortedKeys(keys) {
+			fmt.Printf("%s\t%s\n", key, keys[key])
+		}
+
+	case "verify":
+		flags.Parse(os.Args[2:])
+
+		keys, err := extractKeys(*src, *menus)
+		if err != nil {
+			fail(err)
+		}
+		bundle, err := i18n.LoadDir(*dir, *defaultLang)
+		if err != nil {
+			fail(err)
+		}
+		if !verify(bundle, keys, *strict) {
+			os.Exit(1)
+		}
+
+	default:
+		usage()
+	}
+}
+
+// verify выводит недостающие и неиспользуемые ключи каждого языка.
+// Возвращает false, если найдены ошибки
+func verify(bundle *i18n.Bundle, used map[string]string, strict bool) bool {
+	ok := true
+
+	for _, lang := range bundle.Languages() {
+		catalog := make(map[string]bool)
+		for _, key := range bundle.Keys(lang) {
+			catalog[key] = true
+		}
+
+		var missing, unused []string
+		for _, key := range sortedKeys(used) {
+			if !catalog[key] {
+				missing = append(missing, key)
+			}
+		}
+		for _, key := range bundle.Keys(lang) {
+			if _, exists := used[key]; !exists {
+				unused = append(unused, key)
+			}
+		}
+
+		fmt.Printf("[%s] ключей: %d, недостаёт: %d, не используется: %d\n", lang, len(catalog), len(missing), len(unused))
+		for _, key := range missing {
+			fmt.Printf("  - нет перевода: %s (%s)\n", key, used[key])
+		}
+		for _, key := range unused {
+			fmt.Printf("  - не используется: %s\n", key)
+		}
+
+		if len(missing) > 0 || (strict && len(unused) > 0) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Использование: i18n extract|verify [-src .] [-menus menus] [-locales locales] [-default ru] [-strict]")
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "Ошибка:", err)
+	os.Exit(1)
+}
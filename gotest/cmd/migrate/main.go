@@ -0,0 +1,86 @@
+// Команда migrate управляет миграциями базы данных.
+//
+//	go run ./cmd/migrate up        — применить все новые миграции
+//	go run ./cmd/migrate down [N]  — откатить N последних миграций (по умолчанию 1)
+//	go run ./cmd/migrate status    — показать состояние миграций
+//
+// База выбирается так же, как у бота: DB_DRIVER=postgres или DB_DRIVER=sqlite (файл DB_PATH)
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/database"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		log.Fatal("Ошибка загрузки конфигурации:", err)
+	}
+
+	ctx := context.Background()
+	db, err := database.Open(ctx, *cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, cfg.Driver)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Применено миграций: %d\n", count)
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				usage()
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Откачено миграций: %d\n", count)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, status := range statuses {
+			state := "не применена"
+			if status.Applied() {
+				state = "применена " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%03d %-40s %s\n", status.Version, status.Name, state)
+		}
+
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Использование: migrate up | down [N] | status")
+	os.Exit(2)
+}
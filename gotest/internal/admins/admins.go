@@ -0,0 +1,175 @@
+package admins
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// Service выдаёт и отзывает права администратора.
+// Владельцы бота задаются в ADMIN_IDS и меняются только через окружение,
+// остальных администраторов назначают владельцы командами бота.
+// Права проверяются почти на каждом обновлении, поэтому назначенные
+// администраторы держатся в памяти: хранилище читается только при запуске (Load)
+type Service struct {
+	store    *repository.Store
+	ownerIDs []int64
+
+	mu     sync.RWMutex
+	admins map[int64]domain.Admin // Карта: ID пользователя -> назначенный администратор
+}
+
+// NewService создаёт сервис администраторов. Назначенных администраторов загружает Load
+func NewService(store *repository.Store, ownerIDs []int64) *Service {
+	return &Service{
+		store:    store,
+		ownerIDs: ownerIDs,
+		admins:   make(map[int64]domain.Admin),
+	}
+}
+
+// Load загружает назначенных администраторов из хранилища
+func (s *Service) Load(ctx context.Context) error {
+	admins, err := s.store.Admins.List(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки администраторов: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.admins)
+	for _, admin := range admins {
+		s.admins[admin.UserID] = admin
+	}
+	return nil
+}
+
+// IsOwner проверяет, является ли пользователь владельцем бота (ADMIN_IDS)
+func (s *Service) IsOwner(userID int64) bool {
+	return slices.Contains(s.ownerIDs, userID)
+}
+
+// IsAdmin проверяет, является ли пользователь владельцем или назначенным администратором
+func (s *Service) IsAdmin(userID int64) bool {
+	if s.IsOwner(userID) {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.admins[userID]
+	return exists
+}
+
+// Owners возвращает владельцев бота
+func (s *Service) Owners() []int64 {
+	return slices.Clone(s.ownerIDs)
+}
+
+// List возвращает назначенных администраторов в порядке назначения
+func (s *Service) List() []domain.Admin {
+	s.mu.RLock()
+	admins := make([]domain.Admin, 0, len(s.admins))
+	for _, admin := range s.admins {
+		admins = append(admins, admin)
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(admins, func(a, b domain.Admin) int {
+		if c := a.GrantedAt.Compare(b.GrantedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	return admins
+}
+
+// Grant назначает пользователя администратором и записывает это в журнал аудита.
+// Возвращает false, если пользователь уже владелец или администратор
+func (s *Service) Grant(ctx context.Context, userID, ownerID int64) (bool, error) {
+	if s.IsAdmin(userID) {
+		return false, nil
+	}
+
+	admin := domain.Admin{
+		UserID:    userID,
+		GrantedBy: ownerID,
+		GrantedAt: time.Now().UTC(),
+	}
+
+	var added bool
+	err := s.store.InTx(ctx, func(tx *repository.Store) error {
+		var err error
+		added, err = tx.Admins.Add(ctx, &admin)
+		if err != nil || !added {
+			return err
+		}
+		return tx.Audit.Add(ctx, &domain.AuditEntry{
+			Action:    domain.AuditAdminGranted,
+			ActorID:   ownerID,
+			SubjectID: userID,
+			CreatedAt: admin.GrantedAt,
+		})
+	})
+	if err != nil {
+		return false, fmt.Errorf("ошибка назначения администратора %d: %w", userID, err)
+	}
+	if !added {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	s.admins[userID] = admin
+	s.mu.Unlock()
+
+	log.Printf("Владелец %d назначил пользователя %d администратором", ownerID, userID)
+	return true, nil
+}
+
+// Revoke отзывает права администратора и записывает это в журнал аудита.
+// Возвращает false, если пользователь не был назначенным администратором
+func (s *Service) Revoke(ctx context.Context, userID, ownerID int64) (bool, error) {
+	var deleted bool
+	err := s.store.InTx(ctx, func(tx *repository.Store) error {
+		var err error
+		deleted, err = tx.Admins.Delete(ctx, userID)
+		if err != nil || !deleted {
+			return err
+		}
+		return tx.Audit.Add(ctx, &domain.AuditEntry{
+			Action:    domain.AuditAdminRevoked,
+			ActorID:   ownerID,
+			SubjectID: userID,
+			CreatedAt: time.Now().UTC(),
+		})
+	})
+	if err != nil {
+		return false, fmt.Errorf("ошибка отзыва прав администратора %d: %w", userID, err)
+	}
+
+	s.mu.Lock()
+	delete(s.admins, userID)
+	s.mu.Unlock()
+
+	if deleted {
+		log.Printf("Владелец %d отозвал права администратора у пользователя %d", ownerID, userID)
+	}
+	return deleted, nil
+}
+
+// Forget убирает из памяти права пользователя, удалившего свои данные.
+// Запись в хранилище удаляет privacy.Service.Erase вместе с остальными данными
+func (s *Service) Forget(userID int64) {
+	s.mu.Lock()
+	delete(s.admins, userID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,184 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// Условия аудитории в текстовом виде: "lang=en active=7 subscription=any joined=2026-01-01 chats=private,group"
+const (
+	keyLanguage     = "lang"         // Язык пользователя
+	keyActive       = "active"       // Активен за последние N дней
+	keySubscription = "subscription" // subscribed, unsubscribed или any
+	keyJoined       = "joined"       // Впервые написал боту не раньше даты ГГГГ-ММ-ДД
+	keyChats        = "chats"        // Типы чатов через запятую
+)
+
+// joinedLayout — формат даты в условии joined
+const joinedLayout = "2006-01-02"
+
+var (
+	languagePattern    = regexp.MustCompile(`^[a-z]{2,3}$`)
+	segmentNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+)
+
+// subscriptions и chatTypes — допустимые значения условий subscription и chats
+var (
+	subscriptions = []string{domain.SubscriptionSubscribed, domain.SubscriptionUnsubscribed, domain.SubscriptionAny}
+	chatTypes     = []string{domain.ChatTypePrivate, domain.ChatTypeGroup, domain.ChatTypeSupergroup, domain.ChatTypeChannel}
+)
+
+// ErrSegmentNotFound возвращается, если сегмента с таким именем нет
+var ErrSegmentNotFound = errors.New("сегмент не найден")
+
+// AudienceError — условие аудитории, которое не удалось разобрать
+type AudienceError struct {
+	Condition string // Условие так, как его написал администратор
+}
+
+// Error возвращает текст ошибки
+func (e *AudienceError) Error() string {
+	return fmt.Sprintf("неверное условие аудитории %q", e.Condition)
+}
+
+// ParseAudience разбирает условия аудитории из текста вида "lang=en active=7".
+// Дата в условии joined отсчитывается от полуночи в часовом поясе location
+func ParseAudience(text string, location *time.Location) (domain.Audience, error) {
+	var audience domain.Audience
+	for _, condition := range strings.Fields(strings.ToLower(text)) {
+		key, value, _ := strings.Cut(condition, "=")
+		invalid := &AudienceError{Condition: condition}
+
+		switch key {
+		case keyLanguage:
+			if !languagePattern.MatchString(value) {
+				return audience, invalid
+			}
+			audience.Language = value
+		case keyActive:
+			days, err := strconv.Atoi(value)
+			if err != nil || days <= 0 {
+				return audience, invalid
+			}
+			audience.ActiveDays = days
+		case keySubscription:
+			if !slices.Contains(subscriptions, value) {
+				return audience, invalid
+			}
+			audience.Subscription = value
+		case keyJoined:
+			date, err := time.ParseInLocation(joinedLayout, value, location)
+			if err != nil {
+				return audience, invalid
+			}
+			audience.JoinedAfter = date.UTC()
+		case keyChats:
+			types := strings.Split(value, ",")
+			for _, chatType := range types {
+				if !slices.Contains(chatTypes, chatType) {
+					return audience, invalid
+				}
+			}
+			audience.ChatTypes = types
+		default:
+			return audience, invalid
+		}
+	}
+	return audience, nil
+}
+
+// FormatAudience записывает условия аудитории в том же виде, в каком их принимает ParseAudience.
+// Для аудитории без условий возвращает пустую строку
+func FormatAudience(audience domain.Audience, location *time.Location) string {
+	var conditions []string
+	if len(audience.ChatTypes) > 0 {
+		conditions = append(conditions, keyChats+"="+strings.Join(audience.ChatTypes, ","))
+	}
+	if audience.Language != "" {
+		conditions = append(conditions, keyLanguage+"="+audience.Language)
+	}
+	if audience.ActiveDays > 0 {
+		conditions = append(conditions, keyActive+"="+strconv.Itoa(audience.ActiveDays))
+	}
+	if audience.Subscription != "" {
+		conditions = append(conditions, keySubscription+"="+audience.Subscription)
+	}
+	if !audience.JoinedAfter.IsZero() {
+		conditions = append(conditions, keyJoined+"="+audience.JoinedAfter.In(location).Format(joinedLayout))
+	}
+	return strings.Join(conditions, " ")
+}
+
+// ValidSegmentName сообщает, можно ли сохранить сегмент под таким именем:
+// строчные латинские буквы, цифры, "_" и "-", не длиннее 32 символов
+func ValidSegmentName(name string) bool {
+	return segmentNamePattern.MatchString(name)
+}
+
+// Audience определяет аудиторию рассылки по тексту после команды:
+// имя сохранённого сегмента или условия (см. ParseAudience).
+// Пустой текст — все пользователи, подписанные на рассылки
+func (s *Service) Audience(ctx context.Context, text string, location *time.Location) (domain.Audience, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "=") {
+		return ParseAudience(text, location)
+	}
+
+	segment, err := s.store.Segments.Get(ctx, strings.ToLower(text))
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Audience{}, ErrSegmentNotFound
+	}
+	if err != nil {
+		return domain.Audience{}, fmt.Errorf("ошибка чтения сегмента: %w", err)
+	}
+	return segment.Audience, nil
+}
+
+// Recipients возвращает ID чатов, которым уйдёт рассылка на аудиторию audience:
+// личные чаты подходящих пользователей, а также группы и каналы, где состоит бот
+func (s *Service) Recipients(ctx context.Context, audience domain.Audience) ([]int64, error) {
+	var chatIDs []int64
+
+	if audience.IncludesUsers() {
+		filter := repository.UserFilter{
+			Language:    audience.Language,
+			JoinedSince: audience.JoinedAfter,
+		}
+		if audience.ActiveDays > 0 {
+			filter.ActiveSince = time.Now().AddDate(0, 0, -audience.ActiveDays)
+		}
+		switch audience.Subscription {
+		case "", domain.SubscriptionSubscribed:
+			subscribed := true
+			filter.Notifications = &subscribed
+		case domain.SubscriptionUnsubscribed:
+			subscribed := false
+			filter.Notifications = &subscribed
+		}
+
+		userIDs, err := s.store.Users.ReachableIDs(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка выбора пользователей: %w", err)
+		}
+		chatIDs = append(chatIDs, userIDs...)
+	}
+
+	if groupTypes := audience.GroupTypes(); len(groupTypes) > 0 {
+		groupIDs, err := s.store.Chats.IDs(ctx, repository.ChatFilter{Types: groupTypes, ActiveOnly: true})
+		if err != nil {
+			return nil, fmt.Errorf("ошибка выбора чатов: %w", err)
+		}
+		chatIDs = append(chatIDs, groupIDs...)
+	}
+
+	return chatIDs, nil
+}
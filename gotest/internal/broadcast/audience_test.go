@@ -0,0 +1,130 @@
+package broadcast
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+func TestAudienceRoundTrip(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("нет базы часовых поясов: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		audience domain.Audience
+		format   string // Каноническая запись (пусто — совпадает с text)
+	}{
+		{
+			name: "без условий",
+		},
+		{
+			name:     "язык",
+			text:     "lang=en",
+			audience: domain.Audience{Language: "en"},
+		},
+		{
+			name:     "все условия",
+			text:     "chats=private,group lang=ru active=7 subscription=any joined=2026-01-01",
+			audience: domain.Audience{ChatTypes: []string{"private", "group"}, Language: "ru", ActiveDays: 7, Subscription: "any", JoinedAfter: time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "порядок и регистр не важны",
+			text:     "Active=30  LANG=EN chats=channel",
+			audience: domain.Audience{ChatTypes: []string{"channel"}, Language: "en", ActiveDays: 30},
+			format:   "chats=channel lang=en active=30",
+		},
+		{
+			name:     "отписавшиеся",
+			text:     "subscription=unsubscribed",
+			audience: domain.Audience{Subscription: "unsubscribed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audience, err := ParseAudience(tt.text, moscow)
+			if err != nil {
+				t.Fatalf("ParseAudience(%q) error = %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(audience, tt.audience) {
+				t.Fatalf("ParseAudience(%q) = %+v, want %+v", tt.text, audience, tt.audience)
+			}
+
+			want := tt.format
+			if want == "" {
+				want = tt.text
+			}
+			formatted := FormatAudience(audience, moscow)
+			if formatted != want {
+				t.Fatalf("FormatAudience() = %q, want %q", formatted, want)
+			}
+
+			again, err := ParseAudience(formatted, moscow)
+			if err != nil {
+				t.Fatalf("ParseAudience(%q) error = %v", formatted, err)
+			}
+			if !reflect.DeepEqual(again, audience) {
+				t.Fatalf("round trip = %+v, want %+v", again, audience)
+			}
+		})
+	}
+}
+
+func TestParseAudienceInvalid(t *testing.T) {
+	tests := []struct {
+		text      string
+		condition string // Условие, на которое указывает ошибка
+	}{
+		{"lang=english", "lang=english"},
+		{"lang=", "lang="},
+		{"active=0", "active=0"},
+		{"active=-3", "active=-3"},
+		{"active=week", "active=week"},
+		{"subscription=maybe", "subscription=maybe"},
+		{"joined=01.01.2026", "joined=01.01.2026"},
+		{"chats=private,forum", "chats=private,forum"},
+		{"lang=en country=ru", "country=ru"},
+		{"everyone", "everyone"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseAudience(tt.text, time.UTC)
+		var invalid *AudienceError
+		if !errors.As(err, &invalid) {
+			t.Errorf("ParseAudience(%q) error = %v, want *AudienceError", tt.text, err)
+			continue
+		}
+		if invalid.Condition != tt.condition {
+			t.Errorf("ParseAudience(%q) condition = %q, want %q", tt.text, invalid.Condition, tt.condition)
+		}
+	}
+}
+
+func TestValidSegmentName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"vip", true},
+		{"beta_testers-2", true},
+		{"", false},
+		{"VIP", false},
+		{"с пробелом", false},
+		{"lang=en", false},
+		{"abcdefghijklmnopqrstuvwxyz012345", true},
+		{"abcdefghijklmnopqrstuvwxyz0123456", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidSegmentName(tt.name); got != tt.want {
+			t.Errorf("ValidSegmentName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ErrNoRecipients возвращается, если рассылать некому
+var ErrNoRecipients = errors.New("нет получателей для рассылки")
+
+const (
+	recipientsPageSize = 100 // По скольку получателей читать из хранилища
+	maxAttempts        = 3   // Сколько раз пробовать отправить, если Telegram просит подождать
+)
+
+// Reporter показывает администратору ход рассылки
+type Reporter interface {
+	// Report показывает ход рассылки в сообщении broadcast.ProgressMessageID
+	// (0 — сообщения ещё нет). Возвращает ID сообщения, в котором ход показан:
+	// если старое сообщение удалено, отправляется новое
+	Report(bot *tgbotapi.BotAPI, broadcast *domain.Broadcast, progress repository.BroadcastProgress) (int, error)
+}
+
+// Service рассылает сообщения администраторов пользователям бота, а также в его группы и каналы.
+// Рассылка и результат доставки каждому получателю хранятся в репозитории,
+// поэтому после перезапуска бота незавершённые рассылки продолжаются (см. Resume).
+// Если бот остановился сразу после отправки, получатель может получить сообщение дважды
+type Service struct {
+	bot      *tgbotapi.BotAPI
+	store    *repository.Store
+	settings *settings.Service // Тихие часы получателей
+	reporter Reporter
+	limiter  *time.Ticker  // Общий для всех рассылок: BROADCAST_RATE ограничивает бота, а не одну рассылку
+	progress time.Duration // Как часто обновлять ход рассылки
+
+	mu      sync.Mutex
+	running map[int64]context.CancelFunc // Карта: ID рассылки -> остановка её отправки
+}
+
+// NewService создаёт сервис рассылок
+func NewService(bot *tgbotapi.BotAPI, store *repository.Store, settings *settings.Service, reporter Reporter, cfg config.BroadcastConfig) *Service {
+	rate := max(cfg.Rate, 1)
+	return &Service{
+		bot:      bot,
+		store:    store,
+		settings: settings,
+		reporter: reporter,
+		limiter:  time.NewTicker(time.Second / time.Duration(rate)),
+		progress: cfg.ProgressInterval,
+		running:  make(map[int64]context.CancelFunc),
+	}
+}
+
+// Start сохраняет рассылку вместе со списком получателей из её аудитории и начинает отправку
+func (s *Service) Start(ctx context.Context, broadcast *domain.Broadcast) error {
+	chatIDs, err := s.Recipients(ctx, broadcast.Audience)
+	if err != nil {
+		return err
+	}
+	if len(chatIDs) == 0 {
+		return ErrNoRecipients
+	}
+
+	broadcast.Status = domain.BroadcastRunning
+	broadcast.CreatedAt = time.Now().UTC()
+	err = s.store.InTx(ctx, func(tx *repository.Store) error {
+		if err := tx.Broadcasts.Create(ctx, broadcast); err != nil {
+			return err
+		}
+		return tx.Broadcasts.AddRecipients(ctx, broadcast.ID, chatIDs)
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения рассылки: %w", err)
+	}
+
+	log.Printf("Рассылка #%d запущена администратором %d: %d получателей", broadcast.ID, broadcast.AuthorID, len(chatIDs))
+	s.launch(*broadcast)
+	return nil
+}
+
+// Resume продолжает рассылки, которые не завершились до остановки бота
+func (s *Service) Resume(ctx context.Context) error {
+	broadcasts, err := s.store.Broadcasts.ListRunning(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения рассылок: %w", err)
+	}
+
+	for _, broadcast := range broadcasts {
+		log.Printf("Продолжается рассылка #%d", broadcast.ID)
+		s.launch(broadcast)
+	}
+	return nil
+}
+
+// Cancel останавливает рассылку. Возвращает false, если она уже завершена
+func (s *Service) Cancel(ctx context.Context, id int64) (bool, error) {
+	cancelled, err := s.store.Broadcasts.Finish(ctx, id, domain.BroadcastCancelled, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("ошибка остановки рассылки: %w", err)
+	}
+	if !cancelled {
+		return false, nil
+	}
+	log.Printf("Рассылка #%d остановлена", id)
+
+	// Итог покажет горутина рассылки, когда заметит остановку
+	s.mu.Lock()
+	stop, running := s.running[id]
+	s.mu.Unlock()
+	if running {
+		stop()
+	} else {
+		s.report(id)
+	}
+	return true, nil
+}
+
+// launch запускает отправку рассылки в отдельной горутине
+func (s *Service) launch(broadcast domain.Broadcast) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	s.running[broadcast.ID] = cancel
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, broadcast.ID)
+			s.mu.Unlock()
+			cancel()
+		}()
+		s.run(ctx, broadcast)
+	}()
+}
+
+// run отправляет рассылку оставшимся получателям. Отправки всех идущих рассылок
+// делят один limiter, поэтому вместе они не превышают BROADCAST_RATE
+func (s *Service) run(ctx context.Context, broadcast domain.Broadcast) {
+	s.report(broadcast.ID)
+	reported := time.Now()
+
+	for {
+		chatIDs, err := s.store.Broadcasts.PendingRecipients(ctx, broadcast.ID, recipientsPageSize)
+		if ctx.Err() != nil {
+			s.report(broadcast.ID)
+			return
+		}
+		if err != nil {
+			// Рассылка остаётся незавершённой и продолжится после перезапуска
+			log.Printf("Ошибка чтения получателей рассылки #%d: %v", broadcast.ID, err)
+			return
+		}
+
+		if len(chatIDs) == 0 {
+			if _, err := s.store.Broadcasts.Finish(ctx, broadcast.ID, domain.BroadcastCompleted, time.Now()); err != nil {
+				log.Printf("Ошибка завершения рассылки #%d: %v", broadcast.ID, err)
+			}
+			log.Printf("Рассылка #%d завершена", broadcast.ID)
+			s.report(broadcast.ID)
+			return
+		}
+
+		for _, chatID := range chatIDs {
+			select {
+			case <-ctx.Done():
+				s.report(broadcast.ID)
+				return
+			case <-s.limiter.C:
+			}
+
+			if err := s.deliver(ctx, broadcast, chatID); err != nil {
+				// Без записанного результата получатель остался бы в PendingRecipients
+				// и получал бы сообщение снова и снова. Рассылка продолжится после перезапуска
+				log.Printf("Ошибка записи результата рассылки #%d для чата %d: %v", broadcast.ID, chatID, err)
+				s.report(broadcast.ID)
+				return
+			}
+
+			if time.Since(reported) >= s.progress {
+				s.report(broadcast.ID)
+				reported = time.Now()
+			}
+		}
+	}
+}
+
+// deliver отправляет рассылку в один чат и записывает результат.
+// Пользователю с тихими часами сообщение не отправляется: в результате записывается DeliveryQuiet.
+// Возвращает ошибку, если результат не удалось записать
+func (s *Service) deliver(ctx context.Context, broadcast domain.Broadcast, chatID int64) error {
+	status, errText := domain.DeliverySent, ""
+	// ID личного чата совпадает с ID пользователя; у групп и каналов ID отрицательные
+	if chatID > 0 && s.settings.Get(chatID).IsQuietAt(time.Now()) {
+		status = domain.DeliveryQuiet
+	} else if err := s.send(ctx, broadcast, chatID); err != nil {
+		status, errText = domain.DeliveryFailed, err.Error()
+		if middleware.IsBlockedError(err) {
+			status = domain.DeliveryBlocked
+		}
+	}
+
+	// Результат записывается, даже если рассылку остановили во время отправки
+	ctx = context.WithoutCancel(ctx)
+	if err := s.store.Broadcasts.SetDelivery(ctx, broadcast.ID, chatID, status, errText, time.Now()); err != nil {
+		return err
+	}
+
+	if status == domain.DeliveryBlocked && chatID > 0 {
+		if err := s.store.Users.SetBlocked(ctx, chatID, true); err != nil {
+			log.Printf("Ошибка отметки пользователя %d как заблокировавшего бота: %v", chatID, err)
+		}
+	}
+	return nil
+}
+
+// Message возвращает запрос, которым сообщение рассылки отправляется в чат chatID:
+// пересылку исходного сообщения или его копию от имени бота
+func Message(broadcast domain.Broadcast, chatID int64) tgbotapi.Chattable {
+	if broadcast.Forward {
+		return tgbotapi.NewForward(chatID, broadcast.SourceChatID, broadcast.SourceMessageID)
+	}
+	return tgbotapi.NewCopyMessage(chatID, broadcast.SourceChatID, broadcast.SourceMessageID)
+}
+
+// send копирует (или пересылает) исходное сообщение в чат получателя.
+// Если Telegram просит подождать (429 Too Many Requests), отправка повторяется
+func (s *Service) send(ctx context.Context, broadcast domain.Broadcast, chatID int64) error {
+	message := Message(broadcast, chatID)
+
+	for attempt := 1; ; attempt++ {
+		_, err := s.bot.Request(message)
+
+		var apiErr *tgbotapi.Error
+		if attempt == maxAttempts || !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests {
+			return err
+		}
+
+		wait := time.Duration(max(apiErr.RetryAfter, 1)) * time.Second
+		log.Printf("Telegram ограничил частоту рассылки #%d, пауза %v", broadcast.ID, wait)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// report показывает администратору текущий ход рассылки
+func (s *Service) report(id int64) {
+	ctx := context.Background()
+
+	broadcast, err := s.store.Broadcasts.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Ошибка чтения рассылки #%d: %v", id, err)
+		return
+	}
+	progress, err := s.store.Broadcasts.Progress(ctx, id)
+	if err != nil {
+		log.Printf("Ошибка подсчёта хода рассылки #%d: %v", id, err)
+		return
+	}
+
+	messageID, err := s.reporter.Report(s.bot, broadcast, progress)
+	if err != nil {
+		log.Printf("Ошибка показа хода рассылки #%d: %v", id, err)
+		return
+	}
+	if messageID != broadcast.ProgressMessageID {
+		if err := s.store.Broadcasts.SetProgressMessage(ctx, id, broadcast.ProgressChatID, messageID); err != nil {
+			log.Printf("Ошибка сохранения сообщения с ходом рассылки #%d: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// scheduleTick — как часто проверять, не пора ли отправить запланированные рассылки
+const scheduleTick = 30 * time.Second
+
+// Ошибки рассылок по расписанию
+var (
+	ErrScheduleNotFound = errors.New("запланированная рассылка не найдена")
+	ErrScheduleFinished = errors.New("запланированная рассылка уже отправлена или отменена")
+	ErrScheduleInPast   = errors.New("время отправки уже прошло")
+)
+
+// Scheduler отправляет рассылки по расписанию. Расписание хранится в репозитории,
+// поэтому после перезапуска бота рассылки отправляются как обычно. Если бот был
+// остановлен в момент отправки, рассылка уходит сразу после запуска (повторяющаяся —
+// один раз, сколько бы повторов ни было пропущено)
+type Scheduler struct {
+	broadcasts *Service
+	schedules  repository.ScheduleRepository
+
+	// Изменения администратора и отправка по расписанию не должны перезаписывать друг друга
+	mu sync.Mutex
+}
+
+// NewScheduler создаёт планировщик рассылок. Отправлять рассылки начинает Run
+func NewScheduler(broadcasts *Service, schedules repository.ScheduleRepository) *Scheduler {
+	return &Scheduler{
+		broadcasts: broadcasts,
+		schedules:  schedules,
+	}
+}
+
+// Run отправляет рассылки, время которых наступило, в том числе пропущенные, пока бот не работал.
+// Запускается в отдельной горутине
+func (s *Scheduler) Run() {
+	ticker := time.NewTicker(scheduleTick)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(time.Now())
+		<-ticker.C
+	}
+}
+
+// Schedule сохраняет новую рассылку по расписанию
+func (s *Scheduler) Schedule(ctx context.Context, schedule *domain.ScheduledBroadcast) error {
+	if !schedule.NextRunAt.After(time.Now()) {
+		return ErrScheduleInPast
+	}
+
+	now := time.Now().UTC()
+	schedule.Status = domain.ScheduleActive
+	if schedule.Recurrence == "" {
+		schedule.Recurrence = domain.RecurrenceNone
+	}
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	if err := s.schedules.Create(ctx, schedule); err != nil {
+		return fmt.Errorf("ошибка сохранения запланированной рассылки: %w", err)
+	}
+
+	log.Printf("Рассылка по расписанию #%d запланирована администратором %d на %s",
+		schedule.ID, schedule.AuthorID, schedule.NextRunAt.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// Get возвращает запланированную рассылку
+func (s *Scheduler) Get(ctx context.Context, id int64) (*domain.ScheduledBroadcast, error) {
+	schedule, err := s.schedules.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения запланированной рассылки: %w", err)
+	}
+	return schedule, nil
+}
+
+// List возвращает рассылки, которые ещё будут отправлены, начиная с ближайших
+func (s *Scheduler) List(ctx context.Context) ([]domain.ScheduledBroadcast, error) {
+	schedules, err := s.schedules.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения запланированных рассылок: %w", err)
+	}
+	return schedules, nil
+}
+
+// Edit изменяет ещё не отправленную рассылку: change получает её копию и меняет нужные поля.
+// Возвращает ErrScheduleFinished, если рассылка уже отправлена или отменена,
+// и ErrScheduleInPast, если новое время отправки уже прошло
+func (s *Scheduler) Edit(ctx context.Context, id int64, change func(schedule *domain.ScheduledBroadcast)) (*domain.ScheduledBroadcast, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !schedule.IsActive() {
+		return schedule, ErrScheduleFinished
+	}
+
+	previous := schedule.NextRunAt
+	change(schedule)
+	if !schedule.NextRunAt.Equal(previous) && !schedule.NextRunAt.After(time.Now()) {
+		return schedule, ErrScheduleInPast
+	}
+
+	schedule.UpdatedAt = time.Now().UTC()
+	if err := s.schedules.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("ошибка изменения запланированной рассылки: %w", err)
+	}
+	return schedule, nil
+}
+
+// Cancel отменяет рассылку. Возвращает false, если она уже отправлена или отменена
+func (s *Scheduler) Cancel(ctx context.Context, id int64) (bool, error) {
+	_, err := s.Edit(ctx, id, func(schedule *domain.ScheduledBroadcast) {
+		schedule.Status = domain.ScheduleCancelled
+	})
+	if errors.Is(err, ErrScheduleFinished) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("Рассылка по расписанию #%d отменена", id)
+	return true, nil
+}
+
+// runDue запускает рассылки, время которых наступило к моменту now
+func (s *Scheduler) runDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	schedules, err := s.schedules.Due(ctx, now)
+	if err != nil {
+		log.Printf("Ошибка чтения запланированных рассылок: %v", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		s.run(ctx, schedule, now)
+	}
+}
+
+// run запускает очередную рассылку по расписанию и назначает следующую.
+// Следующее время сохраняется до запуска: если бот остановится посередине,
+// рассылка будет пропущена, а не отправлена дважды
+func (s *Scheduler) run(ctx context.Context, schedule domain.ScheduledBroadcast, now time.Time) {
+	if late := now.Sub(schedule.NextRunAt); late > time.Minute {
+		log.Printf("Рассылка по расписанию #%d отправляется с опозданием на %v", schedule.ID, late.Round(time.Second))
+	}
+
+	if schedule.Recurrence == domain.RecurrenceNone {
+		schedule.Status = domain.ScheduleDone
+	} else {
+		schedule.NextRunAt = NextRun(schedule, now)
+	}
+	schedule.UpdatedAt = now.UTC()
+	if err := s.schedules.Update(ctx, &schedule); err != nil {
+		log.Printf("Ошибка сохранения запланированной рассылки #%d: %v", schedule.ID, err)
+		return
+	}
+
+	broadcast := schedule.Broadcast()
+	err := s.broadcasts.Start(ctx, &broadcast)
+	if errors.Is(err, ErrNoRecipients) {
+		log.Printf("Рассылка по расписанию #%d пропущена: в аудитории нет получателей", schedule.ID)
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка запуска рассылки по расписанию #%d: %v", schedule.ID, err)
+		return
+	}
+
+	schedule.LastBroadcastID = broadcast.ID
+	if err := s.schedules.Update(ctx, &schedule); err != nil {
+		log.Printf("Ошибка сохранения запланированной рассылки #%d: %v", schedule.ID, err)
+	}
+}
+
+// NextRun возвращает первое после now время отправки повторяющейся рассылки.
+// Повторы считаются в часовом поясе рассылки, поэтому время отправки
+// не сдвигается при переходе на летнее время
+func NextRun(schedule domain.ScheduledBroadcast, now time.Time) time.Time {
+	days := 1
+	if schedule.Recurrence == domain.RecurrenceWeekly {
+		days = 7
+	}
+
+	next := schedule.NextRunAt.In(schedule.Location())
+	for !next.After(now) {
+		next = next.AddDate(0, 0, days)
+	}
+	return next.UTC()
+}
@@ -0,0 +1,58 @@
+package broadcast
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+func TestNextRunKeepsLocalTime(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("нет базы часовых поясов: %v", err)
+	}
+
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		recurrence string
+		timeZone   string
+		next       time.Time // Прошлое время отправки (UTC)
+		now        time.Time
+		want       time.Time
+	}{
+		// В Берлине 29.03.2026 часы переводятся вперёд: 09:00 — уже 07:00 UTC, а не 08:00
+		{"каждый день, переход на летнее время", domain.RecurrenceDaily, "Europe/Berlin", utc(3, 28, 8), utc(3, 28, 8), utc(3, 29, 7)},
+		{"каждый день, после перехода", domain.RecurrenceDaily, "Europe/Berlin", utc(3, 29, 7), utc(3, 29, 7), utc(3, 30, 7)},
+		// 25.10.2026 часы переводятся назад: 09:00 снова 08:00 UTC
+		{"каждую неделю, переход на зимнее время", domain.RecurrenceWeekly, "Europe/Berlin", utc(10, 24, 7), utc(10, 24, 7), utc(10, 31, 8)},
+		// В Нью-Йорке переходы в другие дни: 08.03 и 01.11
+		{"Нью-Йорк, летнее время", domain.RecurrenceWeekly, "America/New_York", utc(3, 1, 14), utc(3, 1, 14), utc(3, 8, 13)},
+		{"Нью-Йорк, зимнее время", domain.RecurrenceDaily, "America/New_York", utc(10, 31, 13), utc(10, 31, 13), utc(11, 1, 14)},
+		// Бот не работал: пропущенные отправки не догоняются, берётся ближайшая будущая
+		{"догоняет через переход", domain.RecurrenceDaily, "Europe/Berlin", utc(3, 20, 8), utc(4, 2, 12), utc(4, 3, 7)},
+		{"каждую неделю через переход", domain.RecurrenceWeekly, "Europe/Berlin", utc(3, 2, 8), utc(4, 1, 0), utc(4, 6, 7)},
+		{"время ещё не наступило", domain.RecurrenceDaily, "Europe/Berlin", utc(3, 30, 7), utc(3, 29, 12), utc(3, 30, 7)},
+		// Без пояса время повторяется по UTC
+		{"без пояса", domain.RecurrenceDaily, "", utc(3, 28, 8), utc(3, 28, 8), utc(3, 29, 8)},
+		{"неизвестный пояс", domain.RecurrenceDaily, "Mars/Olympus", utc(3, 28, 8), utc(3, 28, 8), utc(3, 29, 8)},
+	}
+
+	for _, tt := range tests {
+		schedule := domain.ScheduledBroadcast{
+			Recurrence: tt.recurrence,
+			TimeZone:   tt.timeZone,
+			NextRunAt:  tt.next,
+		}
+		got := NextRun(schedule, tt.now)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: NextRun() = %v, want %v", tt.name, got, tt.want)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("%s: NextRun() location = %v, want UTC", tt.name, got.Location())
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -12,33 +13,69 @@ import (
 // Config — главная структура конфигурации приложения
 // Все поля заполняются из переменных окружения
 type Config struct {
-	Bot      BotConfig      // Настройки бота
-	Database DatabaseConfig // Настройки базы данных
-	Logging  LoggingConfig  // Настройки логирования
+	Bot       BotConfig       // Настройки бота
+	Database  DatabaseConfig  // Настройки базы данных
+	Logging   LoggingConfig   // Настройки логирования
+	I18n      I18nConfig      // Настройки локализации
+	Broadcast BroadcastConfig // Настройки рассылок
 }
 
 // BotConfig — настройки Telegram-бота
 type BotConfig struct {
-	Token    string  `envconfig:"BOT_TOKEN" required:"true"` // Токен бота (обязательный)
-	Debug    bool    `envconfig:"BOT_DEBUG" default:"false"` // Режим отладки
-	Timeout  int     `envconfig:"BOT_TIMEOUT" default:"60"`  // Таймаут запросов (секунды)
-	AdminIDs []int64 `envconfig:"ADMIN_IDS"`                 // ID администраторов
+	Token           string        `envconfig:"BOT_TOKEN" required:"true"`                 // Токен бота (обязательный)
+	Debug           bool          `envconfig:"BOT_DEBUG" default:"false"`                 // Режим отладки
+	Timeout         int           `envconfig:"BOT_TIMEOUT" default:"60"`                  // Таймаут запросов (секунды)
+	AdminIDs        []int64       `envconfig:"ADMIN_IDS"`                                 // ID администраторов
+	MenuFile        string        `envconfig:"MENU_FILE" default:"menus/main.yaml"`       // Файл с описанием инлайн-меню
+	DefaultTimeZone string        `envconfig:"DEFAULT_TIME_ZONE" default:"Europe/Moscow"` // Часовой пояс новых пользователей
+	ExportCooldown  time.Duration `envconfig:"EXPORT_COOLDOWN" default:"1h"`              // Как часто пользователь может запрашивать /mydata
 }
 
-// DatabaseConfig — настройки подключения к PostgreSQL
+// DatabaseConfig — настройки хранилища данных: память, PostgreSQL или файл SQLite
 type DatabaseConfig struct {
+	Driver string `envconfig:"DB_DRIVER" default:"sqlite"` // Хранилище: sqlite, postgres или memory (данные теряются при перезапуске)
+	Path   string `envconfig:"DB_PATH" default:"bot.db"`   // Файл базы SQLite (":memory:" — база в памяти)
+
 	Host     string `envconfig:"DB_HOST" default:"localhost"`    // Адрес сервера БД
 	Port     int    `envconfig:"DB_PORT" default:"5432"`         // Порт БД
 	Name     string `envconfig:"DB_NAME" default:"telegram_bot"` // Имя базы данных
 	User     string `envconfig:"DB_USER" default:"postgres"`     // Пользователь БД
 	Password string `envconfig:"DB_PASSWORD" default:""`         // Пароль БД
 	SSLMode  string `envconfig:"DB_SSL_MODE" default:"disable"`  // Режим SSL
+
+	AutoMigrate bool `envconfig:"DB_AUTO_MIGRATE" default:"true"` // Применять миграции при запуске бота
+
+	MaxOpenConns    int           `envconfig:"DB_MAX_OPEN_CONNS" default:"10"`     // Максимум открытых соединений
+	MaxIdleConns    int           `envconfig:"DB_MAX_IDLE_CONNS" default:"5"`      // Максимум простаивающих соединений
+	ConnMaxLifetime time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"30m"` // Сколько живёт соединение
+	ConnMaxIdleTime time.Duration `envconfig:"DB_CONN_MAX_IDLE_TIME" default:"5m"` // Сколько соединение может простаивать
+
+	ConnectRetries    int           `envconfig:"DB_CONNECT_RETRIES" default:"5"`      // Попыток подключения при запуске
+	ConnectRetryDelay time.Duration `envconfig:"DB_CONNECT_RETRY_DELAY" default:"2s"` // Пауза перед первой повторной попыткой
+}
+
+// BroadcastConfig — настройки рассылок администраторов
+type BroadcastConfig struct {
+	Rate             int           `envconfig:"BROADCAST_RATE" default:"20"`              // Сообщений в секунду (Telegram допускает около 30)
+	ProgressInterval time.Duration `envconfig:"BROADCAST_PROGRESS_INTERVAL" default:"3s"` // Как часто обновлять сообщение с ходом рассылки
+}
+
+// I18nConfig — настройки локализации
+type I18nConfig struct {
+	Dir             string        `envconfig:"I18N_DIR" default:"locales"`                   // Каталог с файлами <язык>.json
+	DefaultLanguage string        `envconfig:"I18N_DEFAULT_LANGUAGE" default:"ru"`           // Язык по умолчанию
+	LanguagesFile   string        `envconfig:"I18N_LANGUAGES_FILE" default:"languages.json"` // Языки из прежних версий: переносятся в настройки при запуске
+	Watch           bool          `envconfig:"I18N_WATCH" default:"false"`                   // Перечитывать каталоги при изменении файлов
+	WatchInterval   time.Duration `envconfig:"I18N_WATCH_INTERVAL" default:"5s"`             // Как часто проверять файлы каталогов
 }
 
 // LoggingConfig — настройки логирования
 type LoggingConfig struct {
 	Level string `envconfig:"LOG_LEVEL" default:"info"`   // Уровень логирования (debug, info, warn, error)
 	File  string `envconfig:"LOG_FILE" default:"bot.log"` // Файл для логов
+
+	Interactions          bool          `envconfig:"LOG_INTERACTIONS" default:"true"`           // Сохранять журнал взаимодействий в хранилище
+	InteractionsRetention time.Duration `envconfig:"LOG_INTERACTIONS_RETENTION" default:"720h"` // Сколько хранить журнал (0 — бессрочно)
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -68,6 +105,18 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// LoadDatabase загружает только настройки базы данных.
+// Нужна утилитам (например, cmd/migrate), которым не нужен токен бота
+func LoadDatabase() (*DatabaseConfig, error) {
+	_ = godotenv.Load()
+
+	var cfg DatabaseConfig
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
 // parseAdminIDs парсит строку ADMIN_IDS и заполняет BotConfig.AdminIDs
 func parseAdminIDs(cfg *Config) error {
 	// Получаем значение переменной окружения ADMIN_IDS
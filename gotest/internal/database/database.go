@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	// Драйверы PostgreSQL и SQLite регистрируются при импорте
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+
+	"telegram-bot/internal/config"
+)
+
+// Хранилища, которые можно выбрать в DB_DRIVER
+const (
+	DriverMemory   = "memory"   // Данные в памяти процесса, без БД
+	DriverPostgres = "postgres" // Сервер PostgreSQL
+	DriverSQLite   = "sqlite"   // Встроенная SQLite в одном файле
+)
+
+// DSN формирует строку подключения к PostgreSQL в формате "ключ=значение"
+func DSN(cfg config.DatabaseConfig) string {
+	params := []struct{ key, value string }{
+		{"host", cfg.Host},
+		{"port", fmt.Sprint(cfg.Port)},
+		{"user", cfg.User},
+		{"password", cfg.Password},
+		{"dbname", cfg.Name},
+		{"sslmode", cfg.SSLMode},
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		if param.value == "" {
+			continue
+		}
+		parts = append(parts, param.key+"="+quoteDSNValue(param.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteDSNValue берёт значение в кавычки, если в нём есть пробелы, кавычки или обратная косая черта
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// SQLiteDSN формирует строку подключения к файлу SQLite.
+// Включаются внешние ключи (для ON DELETE CASCADE), ожидание блокировки и журнал WAL;
+// время хранится в текстовом формате, который драйвер читает обратно в time.Time
+func SQLiteDSN(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	if path != ":memory:" {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+	params.Set("_time_format", "sqlite")
+	return "file:" + path + "?" + params.Encode()
+}
+
+// Open подключается к БД, выбранной в cfg.Driver, настраивает пул соединений и ждёт,
+// пока база ответит (с повторными попытками — база может стартовать позже бота)
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	switch cfg.Driver {
+	case DriverPostgres:
+		return openPostgres(ctx, cfg)
+	case DriverSQLite:
+		return openSQLite(ctx, cfg.Path)
+	default:
+		return nil, fmt.Errorf("хранилище %q не является базой данных (ожидалось %s или %s)", cfg.Driver, DriverPostgres, DriverSQLite)
+	}
+}
+
+// openPostgres подключается к серверу PostgreSQL
+func openPostgres(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", DSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия БД: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := pingWithRetry(ctx, db, cfg.ConnectRetries, cfg.ConnectRetryDelay); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ошибка подключения к БД: %w", err)
+	}
+
+	return db, nil
+}
+
+// openSQLite открывает (или создаёт) файл SQLite.
+// Писать в SQLite может только одно соединение, поэтому пул ограничен одним соединением;
+// соединение не пересоздаётся, иначе база ":memory:" терялась бы вместе с ним
+func openSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", SQLiteDSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия БД: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ошибка открытия файла SQLite %s: %w", path, err)
+	}
+
+	return db, nil
+}
+
+// pingWithRetry проверяет соединение. Пауза между попытками удваивается
+func pingWithRetry(ctx context.Context, db *sql.DB, retries int, delay time.Duration) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = db.PingContext(pingCtx)
+		cancel()
+		if err == nil || attempt >= retries {
+			return err
+		}
+
+		log.Printf("БД недоступна (попытка %d из %d): %v. Повтор через %s", attempt+1, retries+1, err, delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
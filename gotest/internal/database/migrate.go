@@ -0,0 +1,283 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFiles — SQL-миграции, встроенные в бинарный файл
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// dialects — базы, для которых миграция может иметь собственный SQL
+var dialects = []string{DriverPostgres, DriverSQLite}
+
+// Migration — одна версия схемы БД
+type Migration struct {
+	Version int64  // Номер версии из имени файла
+	Name    string // Описание из имени файла
+	Up      string // SQL применения
+	Down    string // SQL отката (может быть пустым — тогда откат невозможен)
+}
+
+// MigrationStatus — миграция и время её применения
+type MigrationStatus struct {
+	Migration
+	AppliedAt time.Time // Нулевое значение — ещё не применена
+}
+
+// Applied сообщает, применена ли миграция
+func (s MigrationStatus) Applied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// LoadMigrations читает миграции из каталога migrations в fsys.
+// Имена файлов: 001_create_users.up.sql и 001_create_users.down.sql.
+// Если SQL для какой-то базы отличается, рядом кладётся файл с её названием:
+// 001_create_users.sqlite.up.sql — он заменяет общий файл, когда dialect == "sqlite"
+func LoadMigrations(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	overrides := make(map[string]string) // Карта: "<версия>.<up|down>" -> SQL для dialect
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(name, ".sql")
+		base, direction, found := cutLast(base, ".")
+		if !found || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("миграция %s: ожидалось имя вида 001_name.up.sql или 001_name.down.sql", name)
+		}
+
+		fileDialect := ""
+		if rest, suffix, found := cutLast(base, "."); found && slices.Contains(dialects, suffix) {
+			base, fileDialect = rest, suffix
+		}
+
+		number, title, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("миграция %s: номер версии должен быть числом", name)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: title}
+			byVersion[version] = migration
+		} else if migration.Name != title {
+			return nil, fmt.Errorf("версия %d используется миграциями %q и %q", version, migration.Name, title)
+		}
+
+		if fileDialect != "" && fileDialect != dialect {
+			// Файл для другой базы
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case fileDialect != "":
+			overrides[fmt.Sprintf("%d.%s", version, direction)] = string(data)
+		case direction == "up":
+			migration.Up = string(data)
+		default:
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if up, exists := overrides[fmt.Sprintf("%d.up", version)]; exists {
+			migration.Up = up
+		}
+		if down, exists := overrides[fmt.Sprintf("%d.down", version)]; exists {
+			migration.Down = down
+		}
+		if migration.Up == "" {
+			return nil, fmt.Errorf("у миграции %03d_%s нет файла .up.sql", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// cutLast делит строку по последнему вхождению sep
+func cutLast(s, sep string) (before, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+// Migrator применяет и откатывает миграции.
+// Применённые версии хранятся в таблице schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator создаёт мигратор со встроенными миграциями для базы dialect (DriverPostgres или DriverSQLite)
+func NewMigrator(db *sql.DB, dialect string) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFiles, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка загрузки миграций: %w", err)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up применяет все ещё не применённые миграции по порядку.
+// Каждая миграция выполняется в своей транзакции. Возвращает число применённых
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, done := applied[migration.Version]; done {
+			continue
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+				migration.Version, migration.Name, time.Now().UTC(),
+			)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("ошибка применения миграции %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Down откатывает steps последних применённых миграций. Возвращает число откаченных
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, done := applied[migration.Version]; !done {
+			continue
+		}
+		if migration.Down == "" {
+			return count, fmt.Errorf("у миграции %03d_%s нет файла .down.sql", migration.Version, migration.Name)
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("ошибка отката миграции %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Status возвращает все известные миграции с отметкой о применении
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Migration: migration,
+			AppliedAt: applied[migration.Version],
+		})
+	}
+	return statuses, nil
+}
+
+// applied создаёт таблицу версий (если её нет) и читает применённые версии
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания таблицы schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// inTx выполняет fn в транзакции
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+
+	"telegram-bot/internal/config"
+)
+
+// openTestSQLite открывает пустую базу SQLite в памяти
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), config.DatabaseConfig{Driver: DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// userTables возвращает таблицы базы, кроме служебных
+func userTables(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> 'schema_migrations' ORDER BY name`)
+	if err != nil {
+		t.Fatalf("список таблиц: %v", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("список таблиц: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	return tables
+}
+
+func TestLoadMigrations(t *testing.T) {
+	for _, dialect := range dialects {
+		migrations, err := LoadMigrations(migrationFiles, dialect)
+		if err != nil {
+			t.Fatalf("%s: LoadMigrations() error = %v", dialect, err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("%s: нет миграций", dialect)
+		}
+		for i, migration := range migrations {
+			if migration.Version != int64(i+1) {
+				t.Errorf("%s: миграция %d имеет версию %d", dialect, i+1, migration.Version)
+			}
+			if migration.Up == "" || migration.Down == "" {
+				t.Errorf("%s: у миграции %03d_%s нет SQL применения или отката", dialect, migration.Version, migration.Name)
+			}
+		}
+	}
+}
+
+func TestMigratorUpDownSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	m, err := NewMigrator(db, DriverSQLite)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	total := len(m.migrations)
+
+	// Дважды: откат должен полностью убирать схему, чтобы её можно было создать заново
+	var tables []string
+	for cycle := 1; cycle <= 2; cycle++ {
+		applied, err := m.Up(ctx)
+		if err != nil {
+			t.Fatalf("цикл %d: Up() error = %v", cycle, err)
+		}
+		if applied != total {
+			t.Fatalf("цикл %d: Up() = %d, want %d", cycle, applied, total)
+		}
+		if again, err := m.Up(ctx); err != nil || again != 0 {
+			t.Fatalf("цикл %d: повторный Up() = %d, %v, want 0", cycle, again, err)
+		}
+
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			t.Fatalf("цикл %d: Status() error = %v", cycle, err)
+		}
+		for _, status := range statuses {
+			if !status.Applied() {
+				t.Errorf("цикл %d: миграция %03d_%s не применена", cycle, status.Version, status.Name)
+			}
+		}
+
+		got := userTables(t, db)
+		if cycle == 1 {
+			tables = got
+		} else if !slices.Equal(got, tables) {
+			t.Errorf("цикл %d: таблицы %v, want %v", cycle, got, tables)
+		}
+
+		// Сначала откат одной миграции, затем остальных
+		if n, err := m.Down(ctx, 1); err != nil || n != 1 {
+			t.Fatalf("цикл %d: Down(1) = %d, %v", cycle, n, err)
+		}
+		statuses, err = m.Status(ctx)
+		if err != nil {
+			t.Fatalf("цикл %d: Status() error = %v", cycle, err)
+		}
+		if last := statuses[len(statuses)-1]; last.Applied() {
+			t.Errorf("цикл %d: миграция %03d_%s осталась применённой", cycle, last.Version, last.Name)
+		}
+
+		rolledBack, err := m.Down(ctx, total)
+		if err != nil {
+			t.Fatalf("цикл %d: Down() error = %v", cycle, err)
+		}
+		if rolledBack != total-1 {
+			t.Fatalf("цикл %d: Down() = %d, want %d", cycle, rolledBack, total-1)
+		}
+		if left := userTables(t, db); len(left) != 0 {
+			t.Fatalf("цикл %d: после отката остались таблицы %v", cycle, left)
+		}
+	}
+
+	if len(tables) == 0 {
+		t.Error("миграции не создали таблиц")
+	}
+}
@@ -0,0 +1,14 @@
+-- Пользователи бота
+CREATE TABLE IF NOT EXISTS users (
+    id            BIGINT PRIMARY KEY,             -- Telegram User ID
+    username      TEXT NOT NULL DEFAULT '',       -- Username без @
+    first_name    TEXT NOT NULL DEFAULT '',       -- Имя
+    last_name     TEXT NOT NULL DEFAULT '',       -- Фамилия
+    language_code TEXT NOT NULL DEFAULT '',       -- Язык клиента Telegram
+    is_blocked    BOOLEAN NOT NULL DEFAULT FALSE, -- Пользователь заблокировал бота
+    first_seen_at TIMESTAMP NOT NULL,             -- Первое обращение (UTC)
+    last_seen_at  TIMESTAMP NOT NULL              -- Последнее обращение (UTC)
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE INDEX IF NOT EXISTS idx_users_last_seen_at ON users (last_seen_at);
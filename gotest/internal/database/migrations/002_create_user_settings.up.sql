@@ -0,0 +1,10 @@
+-- Настройки пользователей
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id               BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    language              TEXT NOT NULL DEFAULT '',      -- Язык интерфейса (пусто — язык клиента Telegram)
+    notifications_enabled BOOLEAN NOT NULL DEFAULT TRUE, -- Получать ли рассылки и напоминания
+    time_zone             TEXT NOT NULL DEFAULT '',      -- Часовой пояс IANA
+    quiet_start           INTEGER NOT NULL DEFAULT 0,    -- Начало тихих часов
+    quiet_end             INTEGER NOT NULL DEFAULT 0,    -- Конец тихих часов
+    updated_at            TIMESTAMP NOT NULL             -- Последнее изменение (UTC)
+);
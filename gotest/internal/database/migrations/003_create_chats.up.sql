@@ -0,0 +1,18 @@
+-- Группы и каналы, куда добавлен бот
+CREATE TABLE IF NOT EXISTS chats (
+    id                   BIGINT PRIMARY KEY,             -- Telegram Chat ID
+    type                 TEXT NOT NULL,                  -- group, supergroup или channel
+    title                TEXT NOT NULL DEFAULT '',       -- Название чата
+    username             TEXT NOT NULL DEFAULT '',       -- Публичное имя без @
+    bot_status           TEXT NOT NULL,                  -- Статус бота: administrator, member, left, kicked...
+    can_send_messages    BOOLEAN NOT NULL DEFAULT FALSE, -- Бот может писать в чат
+    can_delete_messages  BOOLEAN NOT NULL DEFAULT FALSE, -- Бот может удалять сообщения
+    can_restrict_members BOOLEAN NOT NULL DEFAULT FALSE, -- Бот может ограничивать участников
+    can_pin_messages     BOOLEAN NOT NULL DEFAULT FALSE, -- Бот может закреплять сообщения
+    can_invite_users     BOOLEAN NOT NULL DEFAULT FALSE, -- Бот может приглашать участников
+    joined_at            TIMESTAMP NOT NULL,             -- Когда бота добавили (UTC)
+    left_at              TIMESTAMP NULL,                 -- Когда бот покинул чат (UTC), NULL — состоит в чате
+    updated_at           TIMESTAMP NOT NULL              -- Последнее изменение (UTC)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chats_type_status ON chats (type, bot_status);
@@ -0,0 +1,11 @@
+-- Журнал аудита (SQLite: вместо BIGSERIAL — INTEGER PRIMARY KEY AUTOINCREMENT)
+CREATE TABLE IF NOT EXISTS audit_log (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    action     TEXT NOT NULL,            -- Что произошло: user.erased...
+    actor_id   BIGINT NOT NULL,          -- Кто выполнил действие
+    subject_id BIGINT NOT NULL,          -- Чьи данные затронуты
+    details    TEXT NOT NULL DEFAULT '', -- Подробности (без личных данных)
+    created_at TIMESTAMP NOT NULL        -- Когда (UTC)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_subject_id ON audit_log (subject_id);
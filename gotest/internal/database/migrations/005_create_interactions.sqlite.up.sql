@@ -0,0 +1,20 @@
+-- Журнал взаимодействий (SQLite: вместо BIGSERIAL — INTEGER PRIMARY KEY AUTOINCREMENT)
+CREATE TABLE IF NOT EXISTS interactions (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    update_id   BIGINT NOT NULL,          -- ID обновления Telegram
+    update_type TEXT NOT NULL,            -- message, callback_query, my_chat_member...
+    chat_id     BIGINT NOT NULL,          -- Чат (0 — без чата)
+    user_id     BIGINT NOT NULL,          -- Отправитель (0 — неизвестен)
+    command     TEXT NOT NULL DEFAULT '', -- Команда без "/"
+    text        TEXT NOT NULL DEFAULT '', -- Текст, подпись или данные кнопки
+    file_id     TEXT NOT NULL DEFAULT '', -- File ID вложения
+    handler     TEXT NOT NULL DEFAULT '', -- Кто обработал обновление
+    latency_ms  BIGINT NOT NULL,          -- Время обработки, мс
+    outcome     TEXT NOT NULL,            -- ok, error, ignored
+    error       TEXT NOT NULL DEFAULT '', -- Текст ошибки
+    created_at  TIMESTAMP NOT NULL        -- Когда обновление получено (UTC)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interactions_user_id_created_at ON interactions (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_interactions_chat_id_created_at ON interactions (chat_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_interactions_created_at ON interactions (created_at);
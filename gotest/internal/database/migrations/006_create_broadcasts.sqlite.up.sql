@@ -0,0 +1,27 @@
+-- Рассылки администраторов (SQLite: вместо BIGSERIAL — INTEGER PRIMARY KEY AUTOINCREMENT)
+CREATE TABLE IF NOT EXISTS broadcasts (
+    id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+    author_id           BIGINT NOT NULL,            -- Администратор, запустивший рассылку
+    source_chat_id      BIGINT NOT NULL,            -- Чат с исходным сообщением
+    source_message_id   BIGINT NOT NULL,            -- Исходное сообщение
+    forward             BOOLEAN NOT NULL,           -- Пересылать, а не копировать
+    status              TEXT NOT NULL,              -- running, cancelled, completed
+    progress_chat_id    BIGINT NOT NULL DEFAULT 0,  -- Чат сообщения с ходом рассылки
+    progress_message_id BIGINT NOT NULL DEFAULT 0,  -- Сообщение с ходом рассылки
+    created_at          TIMESTAMP NOT NULL,         -- Когда запущена (UTC)
+    finished_at         TIMESTAMP                   -- Когда завершена (NULL — идёт)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcasts_status ON broadcasts (status);
+
+-- Получатели рассылки и результат доставки каждому
+CREATE TABLE IF NOT EXISTS broadcast_recipients (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    user_id      BIGINT NOT NULL,                   -- Получатель
+    status       TEXT NOT NULL DEFAULT 'pending',   -- pending, sent, blocked, failed
+    error        TEXT NOT NULL DEFAULT '',          -- Текст ошибки Telegram
+    sent_at      TIMESTAMP,                         -- Когда отправлено (NULL — ещё нет)
+    PRIMARY KEY (broadcast_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcast_recipients_user_id ON broadcast_recipients (user_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_broadcast_recipients_chat_id;
+ALTER TABLE broadcast_recipients RENAME COLUMN chat_id TO user_id;
+CREATE INDEX IF NOT EXISTS idx_broadcast_recipients_user_id ON broadcast_recipients (user_id);
+
+ALTER TABLE broadcasts DROP COLUMN audience;
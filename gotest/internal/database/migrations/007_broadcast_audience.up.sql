@@ -0,0 +1,7 @@
+-- Аудитория рассылки; пустая строка — все пользователи (рассылки до сегментов)
+ALTER TABLE broadcasts ADD COLUMN audience TEXT NOT NULL DEFAULT '';
+
+-- Рассылки уходят не только пользователям, но и в группы и каналы
+ALTER TABLE broadcast_recipients RENAME COLUMN user_id TO chat_id;
+DROP INDEX IF EXISTS idx_broadcast_recipients_user_id;
+CREATE INDEX IF NOT EXISTS idx_broadcast_recipients_chat_id ON broadcast_recipients (chat_id);
@@ -0,0 +1,7 @@
+-- Сохранённые аудитории рассылок
+CREATE TABLE IF NOT EXISTS segments (
+    name       TEXT PRIMARY KEY,      -- Имя сегмента
+    audience   TEXT NOT NULL,         -- Условия отбора (JSON)
+    created_by BIGINT NOT NULL,       -- Администратор, сохранивший сегмент
+    updated_at TIMESTAMP NOT NULL     -- Последнее изменение (UTC)
+);
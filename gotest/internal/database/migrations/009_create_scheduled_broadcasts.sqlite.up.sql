@@ -0,0 +1,18 @@
+-- Рассылки по расписанию: разовые и повторяющиеся (SQLite: вместо BIGSERIAL — INTEGER PRIMARY KEY AUTOINCREMENT)
+CREATE TABLE IF NOT EXISTS scheduled_broadcasts (
+    id                INTEGER PRIMARY KEY AUTOINCREMENT,
+    author_id         BIGINT NOT NULL,                  -- Администратор, запланировавший рассылку
+    source_chat_id    BIGINT NOT NULL,                  -- Чат с исходным сообщением
+    source_message_id BIGINT NOT NULL,                  -- Исходное сообщение
+    forward           BOOLEAN NOT NULL,                 -- Пересылать, а не копировать
+    audience          TEXT NOT NULL,                    -- Кому отправляется (JSON)
+    recurrence        TEXT NOT NULL DEFAULT 'none',     -- none, daily, weekly
+    time_zone         TEXT NOT NULL DEFAULT '',         -- Часовой пояс повторов (IANA)
+    next_run_at       TIMESTAMP NOT NULL,               -- Следующая отправка (UTC)
+    status            TEXT NOT NULL DEFAULT 'active',   -- active, cancelled, done
+    last_broadcast_id BIGINT NOT NULL DEFAULT 0,        -- Последняя запущенная рассылка
+    created_at        TIMESTAMP NOT NULL,               -- Когда запланирована (UTC)
+    updated_at        TIMESTAMP NOT NULL                -- Последнее изменение (UTC)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_broadcasts_status_next_run_at ON scheduled_broadcasts (status, next_run_at);
@@ -0,0 +1,9 @@
+-- Ограничения пользователей: блокировка (ban) и запрет писать боту (mute)
+CREATE TABLE IF NOT EXISTS user_restrictions (
+    user_id    BIGINT PRIMARY KEY,        -- Ограниченный пользователь (мог ещё не писать боту)
+    kind       TEXT NOT NULL,             -- ban или mute
+    reason     TEXT NOT NULL DEFAULT '',  -- Причина
+    created_by BIGINT NOT NULL,           -- Администратор, наложивший ограничение
+    created_at TIMESTAMP NOT NULL,        -- Когда наложено (UTC)
+    expires_at TIMESTAMP                  -- Когда снимается само (NULL — бессрочно)
+);
@@ -0,0 +1,6 @@
+-- Администраторы, назначенные владельцами бота (владельцы задаются в ADMIN_IDS)
+CREATE TABLE IF NOT EXISTS admins (
+    user_id    BIGINT PRIMARY KEY,  -- Администратор (мог ещё не писать боту)
+    granted_by BIGINT NOT NULL,     -- Владелец, выдавший права
+    granted_at TIMESTAMP NOT NULL   -- Когда выданы права (UTC)
+);
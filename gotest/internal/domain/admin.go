@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Admin — администратор, назначенный владельцем бота командой /grant.
+// Владельцы (ADMIN_IDS) в хранилище не попадают: они задаются только окружением
+type Admin struct {
+	UserID    int64     `json:"user_id" db:"user_id"`       // Администратор
+	GrantedBy int64     `json:"granted_by" db:"granted_by"` // Владелец, выдавший права
+	GrantedAt time.Time `json:"granted_at" db:"granted_at"` // Когда выданы права (UTC)
+}
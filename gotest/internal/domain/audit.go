@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Действия, которые записываются в журнал аудита
+const (
+	AuditUserErased       = "user.erased"       // Данные пользователя удалены по его запросу
+	AuditUserExported     = "user.exported"     // Пользователь выгрузил свои данные (/mydata)
+	AuditUserRestricted   = "user.restricted"   // Администратор заблокировал пользователя или запретил ему писать
+	AuditUserUnrestricted = "user.unrestricted" // Администратор снял ограничение с пользователя
+	AuditAdminGranted     = "admin.granted"     // Владелец назначил пользователя администратором
+	AuditAdminRevoked     = "admin.revoked"     // Владелец отозвал права администратора
+)
+
+// AuditEntry — запись журнала аудита
+type AuditEntry struct {
+	ID        int64     `json:"id" db:"id"`                 // Номер записи
+	Action    string    `json:"action" db:"action"`         // Что произошло (Audit*)
+	ActorID   int64     `json:"actor_id" db:"actor_id"`     // Кто выполнил действие
+	SubjectID int64     `json:"subject_id" db:"subject_id"` // Чьи данные затронуты
+	Details   string    `json:"details" db:"details"`       // Подробности (без личных данных)
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // Когда (UTC)
+}
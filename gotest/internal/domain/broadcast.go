@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Состояния рассылки
+const (
+	BroadcastRunning   = "running"   // Рассылка идёт (после перезапуска бота продолжается)
+	BroadcastCancelled = "cancelled" // Администратор остановил рассылку
+	BroadcastCompleted = "completed" // Сообщение отправлено всем получателям
+)
+
+// Результаты доставки рассылки получателю
+const (
+	DeliveryPending = "pending" // Ещё не отправлено
+	DeliverySent    = "sent"    // Доставлено
+	DeliveryBlocked = "blocked" // Пользователь заблокировал бота
+	DeliveryFailed  = "failed"  // Другая ошибка Telegram
+	DeliveryQuiet   = "quiet"   // Не отправлено: у пользователя были тихие часы
+)
+
+// Broadcast — рассылка сообщения пользователям бота.
+// Рассылаемое сообщение не хранится: бот копирует (или пересылает) исходное
+// сообщение администратора из чата, где тот его прислал
+type Broadcast struct {
+	ID                int64     `json:"id" db:"id"`                                   // Номер рассылки
+	AuthorID          int64     `json:"author_id" db:"author_id"`                     // Администратор, запустивший рассылку
+	SourceChatID      int64     `json:"source_chat_id" db:"source_chat_id"`           // Чат с исходным сообщением
+	SourceMessageID   int       `json:"source_message_id" db:"source_message_id"`     // Исходное сообщение
+	Forward           bool      `json:"forward" db:"forward"`                         // Пересылать с подписью источника, а не копировать
+	Audience          Audience  `json:"audience" db:"audience"`                       // Кому отправляется (хранится в JSON)
+	Status            string    `json:"status" db:"status"`                           // Состояние (Broadcast*)
+	ProgressChatID    int64     `json:"progress_chat_id" db:"progress_chat_id"`       // Чат сообщения с ходом рассылки
+	ProgressMessageID int       `json:"progress_message_id" db:"progress_message_id"` // Сообщение с ходом рассылки (0 — нет)
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`                   // Когда рассылка запущена (UTC)
+	FinishedAt        time.Time `json:"finished_at" db:"finished_at"`                 // Когда завершена или остановлена (нулевое — идёт)
+}
+
+// IsFinished сообщает, что рассылка больше не отправляется
+func (b *Broadcast) IsFinished() bool {
+	return b.Status != BroadcastRunning
+}
+
+// Delivery — результат доставки рассылки в один чат
+type Delivery struct {
+	BroadcastID int64     `json:"broadcast_id" db:"broadcast_id"` // Рассылка
+	ChatID      int64     `json:"chat_id" db:"chat_id"`           // Чат-получатель
+	Status      string    `json:"status" db:"status"`             // Результат (Delivery*)
+	Error       string    `json:"error" db:"error"`               // Текст ошибки Telegram
+	SentAt      time.Time `json:"sent_at" db:"sent_at"`           // Когда отправлено (нулевое — ещё нет)
+}
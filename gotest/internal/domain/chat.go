@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// Статусы бота в чате (значения status из ChatMember Telegram Bot API)
+const (
+	ChatStatusCreator       = "creator"
+	ChatStatusAdministrator = "administrator"
+	ChatStatusMember        = "member"
+	ChatStatusRestricted    = "restricted"
+	ChatStatusLeft          = "left"
+	ChatStatusKicked        = "kicked"
+)
+
+// Типы чатов, в которых может состоять бот (кроме личных)
+const (
+	ChatTypeGroup      = "group"
+	ChatTypeSupergroup = "supergroup"
+	ChatTypeChannel    = "channel"
+)
+
+// Chat — группа или канал, куда добавлен бот
+type Chat struct {
+	ID                 int64     `json:"id" db:"id"`                                     // Telegram Chat ID
+	Type               string    `json:"type" db:"type"`                                 // group, supergroup или channel
+	Title              string    `json:"title" db:"title"`                               // Название чата
+	Username           string    `json:"username" db:"username"`                         // Публичное имя (без @, может быть пустым)
+	BotStatus          string    `json:"bot_status" db:"bot_status"`                     // Статус бота в чате (ChatStatus*)
+	CanSendMessages    bool      `json:"can_send_messages" db:"can_send_messages"`       // Бот может писать в чат
+	CanDeleteMessages  bool      `json:"can_delete_messages" db:"can_delete_messages"`   // Бот может удалять сообщения
+	CanRestrictMembers bool      `json:"can_restrict_members" db:"can_restrict_members"` // Бот может ограничивать участников
+	CanPinMessages     bool      `json:"can_pin_messages" db:"can_pin_messages"`         // Бот может закреплять сообщения
+	CanInviteUsers     bool      `json:"can_invite_users" db:"can_invite_users"`         // Бот может приглашать участников
+	JoinedAt           time.Time `json:"joined_at" db:"joined_at"`                       // Когда бота добавили в чат (последний раз)
+	LeftAt             time.Time `json:"left_at" db:"left_at"`                           // Когда бот покинул чат (нулевое — состоит в чате)
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`                     // Последнее изменение
+}
+
+// IsActive сообщает, что бот сейчас состоит в чате
+func (c Chat) IsActive() bool {
+	return IsActiveChatStatus(c.BotStatus)
+}
+
+// IsActiveChatStatus сообщает, что со статусом status бот состоит в чате
+func IsActiveChatStatus(status string) bool {
+	switch status {
+	case ChatStatusCreator, ChatStatusAdministrator, ChatStatusMember, ChatStatusRestricted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Результаты обработки обновления
+const (
+	OutcomeOK      = "ok"      // Обработчик завершился без ошибки
+	OutcomeError   = "error"   // Обработчик вернул ошибку
+	OutcomeIgnored = "ignored" // Для обновления не нашлось обработчика
+)
+
+// Interaction — запись журнала взаимодействий: одно обновление и то, как бот его обработал
+type Interaction struct {
+	ID         int64         `json:"id" db:"id"`                   // Номер записи
+	UpdateID   int           `json:"update_id" db:"update_id"`     // ID обновления Telegram
+	UpdateType string        `json:"update_type" db:"update_type"` // message, callback_query, my_chat_member...
+	ChatID     int64         `json:"chat_id" db:"chat_id"`         // Чат (0 — обновление без чата)
+	UserID     int64         `json:"user_id" db:"user_id"`         // Отправитель (0 — неизвестен)
+	Command    string        `json:"command" db:"command"`         // Команда без "/" (пусто — не команда)
+	Text       string        `json:"text" db:"text"`               // Текст, подпись или данные кнопки (обрезается)
+	FileID     string        `json:"file_id" db:"file_id"`         // File ID вложения (пусто — без файла)
+	Handler    string        `json:"handler" db:"handler"`         // Кто обработал: command:start, callback:set, message
+	Latency    time.Duration `json:"latency" db:"latency_ms"`      // Время обработки (хранится в миллисекундах)
+	Outcome    string        `json:"outcome" db:"outcome"`         // Результат (Outcome*)
+	Error      string        `json:"error" db:"error"`             // Текст ошибки (пусто — без ошибки)
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`   // Когда обновление получено (UTC)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Виды ограничений пользователя
+const (
+	RestrictionBan  = "ban"  // Бот молча игнорирует все обновления пользователя
+	RestrictionMute = "mute" // Бот вежливо отказывает пользователю, не выполняя его команды
+)
+
+// Restriction — ограничение, наложенное администратором на пользователя.
+// У пользователя может быть только одно ограничение: новое заменяет прежнее
+type Restriction struct {
+	UserID    int64     `json:"user_id" db:"user_id"`       // Ограниченный пользователь
+	Kind      string    `json:"kind" db:"kind"`             // Вид ограничения (Restriction*)
+	Reason    string    `json:"reason" db:"reason"`         // Причина (может быть пустой)
+	CreatedBy int64     `json:"created_by" db:"created_by"` // Администратор, наложивший ограничение
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // Когда наложено (UTC)
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"` // Когда снимается само (нулевое — бессрочно)
+}
+
+// IsPermanent сообщает, что ограничение бессрочное
+func (r Restriction) IsPermanent() bool {
+	return r.ExpiresAt.IsZero()
+}
+
+// IsActiveAt сообщает, действует ли ограничение в момент t
+func (r Restriction) IsActiveAt(t time.Time) bool {
+	return r.IsPermanent() || t.Before(r.ExpiresAt)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Повтор запланированной рассылки
+const (
+	RecurrenceNone   = "none"   // Один раз
+	RecurrenceDaily  = "daily"  // Каждый день в то же время
+	RecurrenceWeekly = "weekly" // Каждую неделю в тот же день и время
+)
+
+// Состояния запланированной рассылки
+const (
+	ScheduleActive    = "active"    // Ждёт времени отправки
+	ScheduleCancelled = "cancelled" // Администратор отменил рассылку
+	ScheduleDone      = "done"      // Разовая рассылка отправлена
+)
+
+// ScheduledBroadcast — рассылка, которая отправляется в заданное время, один раз или с повтором.
+// Как и у обычной рассылки, сообщение не хранится: в момент отправки бот копирует
+// (или пересылает) исходное сообщение администратора, поэтому удалять его нельзя
+type ScheduledBroadcast struct {
+	ID              int64     `json:"id" db:"id"`                               // Номер запланированной рассылки
+	AuthorID        int64     `json:"author_id" db:"author_id"`                 // Администратор, запланировавший рассылку
+	SourceChatID    int64     `json:"source_chat_id" db:"source_chat_id"`       // Чат с исходным сообщением
+	SourceMessageID int       `json:"source_message_id" db:"source_message_id"` // Исходное сообщение
+	Forward         bool      `json:"forward" db:"forward"`                     // Пересылать с подписью источника, а не копировать
+	Audience        Audience  `json:"audience" db:"audience"`                   // Кому отправляется (хранится в JSON)
+	Recurrence      string    `json:"recurrence" db:"recurrence"`               // Повтор (Recurrence*)
+	TimeZone        string    `json:"time_zone" db:"time_zone"`                 // Часовой пояс, в котором повторяется время отправки
+	NextRunAt       time.Time `json:"next_run_at" db:"next_run_at"`             // Когда отправить в следующий раз (UTC)
+	Status          string    `json:"status" db:"status"`                       // Состояние (Schedule*)
+	LastBroadcastID int64     `json:"last_broadcast_id" db:"last_broadcast_id"` // Последняя запущенная рассылка (0 — ещё не было)
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`               // Когда запланирована (UTC)
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`               // Последнее изменение (UTC)
+}
+
+// IsActive сообщает, что рассылка ещё будет отправлена
+func (s *ScheduledBroadcast) IsActive() bool {
+	return s.Status == ScheduleActive
+}
+
+// Location возвращает часовой пояс рассылки (UTC, если пояс не задан или неизвестен)
+func (s *ScheduledBroadcast) Location() *time.Location {
+	if s.TimeZone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Broadcast возвращает рассылку, которая запускается в очередной раз по расписанию.
+// Ход рассылки показывается в чате, где администратор прислал сообщение
+func (s *ScheduledBroadcast) Broadcast() Broadcast {
+	return Broadcast{
+		AuthorID:        s.AuthorID,
+		SourceChatID:    s.SourceChatID,
+		SourceMessageID: s.SourceMessageID,
+		Forward:         s.Forward,
+		Audience:        s.Audience,
+		ProgressChatID:  s.SourceChatID,
+	}
+}
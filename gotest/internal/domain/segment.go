@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ChatTypePrivate — личный чат с пользователем
+const ChatTypePrivate = "private"
+
+// Отношение пользователя к рассылкам (настройка "Уведомления")
+const (
+	SubscriptionSubscribed   = "subscribed"   // Уведомления включены
+	SubscriptionUnsubscribed = "unsubscribed" // Уведомления выключены
+	SubscriptionAny          = "any"          // Не важно
+)
+
+// Audience — кому отправляется рассылка. Условия объединяются через "и";
+// пустые условия не ограничивают выбор. Пользователи, заблокировавшие бота,
+// в аудиторию не попадают никогда
+type Audience struct {
+	ChatTypes    []string  `json:"chat_types,omitempty"`   // Типы чатов (пусто — только личные чаты)
+	Language     string    `json:"language,omitempty"`     // Язык пользователя
+	ActiveDays   int       `json:"active_days,omitempty"`  // Обращался к боту за последние N дней
+	Subscription string    `json:"subscription,omitempty"` // Subscription* (пусто — SubscriptionSubscribed)
+	JoinedAfter  time.Time `json:"joined_after,omitzero"`  // Впервые написал боту не раньше
+}
+
+// IncludesUsers сообщает, что рассылка уходит пользователям в личные чаты.
+// Условия на язык, активность, подписку и дату первого обращения относятся только к ним
+func (a Audience) IncludesUsers() bool {
+	return len(a.ChatTypes) == 0 || slices.Contains(a.ChatTypes, ChatTypePrivate)
+}
+
+// GroupTypes возвращает типы групп и каналов, в которые уходит рассылка
+func (a Audience) GroupTypes() []string {
+	var types []string
+	for _, chatType := range a.ChatTypes {
+		if chatType != ChatTypePrivate {
+			types = append(types, chatType)
+		}
+	}
+	return types
+}
+
+// Segment — сохранённая под именем аудитория рассылок
+type Segment struct {
+	Name      string    `json:"name" db:"name"`             // Имя, по которому сегмент выбирается в /broadcast
+	Audience  Audience  `json:"audience" db:"audience"`     // Условия отбора (хранятся в JSON)
+	CreatedBy int64     `json:"created_by" db:"created_by"` // Администратор, сохранивший сегмент
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // Последнее изменение (UTC)
+}
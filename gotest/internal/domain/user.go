@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// User — пользователь бота
+type User struct {
+	ID           int64     `json:"id" db:"id"`                       // Telegram User ID
+	Username     string    `json:"username" db:"username"`           // Username (без @, может быть пустым)
+	FirstName    string    `json:"first_name" db:"first_name"`       // Имя
+	LastName     string    `json:"last_name" db:"last_name"`         // Фамилия
+	LanguageCode string    `json:"language_code" db:"language_code"` // Язык клиента Telegram
+	IsBlocked    bool      `json:"is_blocked" db:"is_blocked"`       // Пользователь заблокировал бота
+	FirstSeenAt  time.Time `json:"first_seen_at" db:"first_seen_at"` // Первое обращение к боту
+	LastSeenAt   time.Time `json:"last_seen_at" db:"last_seen_at"`   // Последнее обращение к боту
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// UserSettings — настройки пользователя
+type UserSettings struct {
+	UserID               int64     `json:"user_id" db:"user_id"`
+	Language             string    `json:"language" db:"language"`                           // Язык интерфейса (пусто — язык клиента Telegram)
+	NotificationsEnabled bool      `json:"notifications_enabled" db:"notifications_enabled"` // Получать ли рассылки и напоминания
+	TimeZone             string    `json:"time_zone" db:"time_zone"`                         // Часовой пояс IANA, например Europe/Moscow
+	QuietStart           int       `json:"quiet_start" db:"quiet_start"`                     // Начало тихих часов (час 0–23)
+	QuietEnd             int       `json:"quiet_end" db:"quiet_end"`                         // Конец тихих часов (час 0–23); равен началу — тихих часов нет
+	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`                       // Когда настройки менялись последний раз
+}
+
+// Location возвращает часовой пояс пользователя (UTC, если пояс не задан или неизвестен)
+func (s UserSettings) Location() *time.Location {
+	if s.TimeZone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// HasQuietHours сообщает, включены ли тихие часы
+func (s UserSettings) HasQuietHours() bool {
+	return s.QuietStart != s.QuietEnd
+}
+
+// IsQuietAt сообщает, приходится ли момент t на тихие часы пользователя.
+// Интервал может переходить через полночь: 23–8 означает с 23:00 до 08:00
+func (s UserSettings) IsQuietAt(t time.Time) bool {
+	if !s.HasQuietHours() {
+		return false
+	}
+
+	hour := t.In(s.Location()).Hour()
+	if s.QuietStart < s.QuietEnd {
+		return hour >= s.QuietStart && hour < s.QuietEnd
+	}
+	return hour >= s.QuietStart || hour < s.QuietEnd
+}
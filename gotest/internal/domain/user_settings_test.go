@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserSettingsIsQuietAt(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name       string
+		start, end int
+		timeZone   string
+		at         time.Time
+		want       bool
+	}{
+		{"без тихих часов", 0, 0, "", time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC), false},
+		{"внутри дневного интервала", 13, 15, "", time.Date(2026, 10, 18, 14, 59, 0, 0, time.UTC), true},
+		{"конец интервала не входит", 13, 15, "", time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC), false},
+		{"через полночь, вечер", 23, 8, "", time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC), true},
+		{"через полночь, утро", 23, 8, "", time.Date(2026, 10, 18, 7, 59, 0, 0, time.UTC), true},
+		{"через полночь, день", 23, 8, "", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), false},
+		// 21:00 UTC — полночь в Москве
+		{"в поясе пользователя", 0, 7, "Europe/Moscow", time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC), true},
+		{"время в другом поясе", 0, 7, "", time.Date(2026, 10, 19, 1, 0, 0, 0, moscow), false},
+	}
+
+	for _, tt := range tests {
+		if tt.timeZone != "" {
+			if _, err := time.LoadLocation(tt.timeZone); err != nil {
+				t.Skipf("нет базы часовых поясов: %v", err)
+			}
+		}
+		settings := UserSettings{QuietStart: tt.start, QuietEnd: tt.end, TimeZone: tt.timeZone}
+		if got := settings.IsQuietAt(tt.at); got != tt.want {
+			t.Errorf("%s: IsQuietAt(%v) = %v, want %v", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"telegram-bot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// AboutHandler обрабатывает команду /about
+type AboutHandler struct {
+	loc *i18n.Localizer
+}
+
+// NewAboutHandler создаёт новый обработчик команды /about
+func NewAboutHandler(loc *i18n.Localizer) *AboutHandler {
+	return &AboutHandler{
+		loc: loc,
+	}
+}
+
+// Command возвращает команду
+func (h *AboutHandler) Command() string {
+	return "about"
+}
+
+// Handle обрабатывает команду /about
+func (h *AboutHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	text := h.loc.For(msg.From).T("about.text")
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	_, err := bot.Send(reply)
+	return err
+}
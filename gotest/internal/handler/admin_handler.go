@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/middleware"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -9,13 +9,15 @@ import (
 
 // AdminHandler обрабатывает команду /admin
 type AdminHandler struct {
-	adminIDs []int64
+	admins middleware.AdminChecker
+	loc    *i18n.Localizer
 }
 
 // NewAdminHandler создаёт новый обработчик команды /admin
-func NewAdminHandler(adminIDs []int64) *AdminHandler {
+func NewAdminHandler(admins middleware.AdminChecker, loc *i18n.Localizer) *AdminHandler {
 	return &AdminHandler{
-		adminIDs: adminIDs,
+		admins: admins,
+		loc:    loc,
 	}
 }
 
@@ -26,28 +28,30 @@ func (h *AdminHandler) Command() string {
 
 // Handle обрабатывает команду /info
 func (h *AdminHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+
 	// Проверяем права доступа
-	if !middleware.RequireAdmin(bot, msg, h.adminIDs) {
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
 		return nil // Сообщение уже отправлено middleware
 	}
 
 	chatID := msg.Chat.ID
 	user := msg.From
 
-	info := "<b>Информация о вас:</b>\n\n"
-	info += fmt.Sprintf("<b>ID:</b> <code>%d</code>\n", user.ID)
-	info += fmt.Sprintf("<b>Имя:</b> %s\n", user.FirstName)
+	info := tr.T("info.title")
+	info += tr.T("info.id", i18n.Args{"id": user.ID})
+	info += tr.T("info.first_name", i18n.Args{"name": user.FirstName})
 
 	if user.LastName != "" {
-		info += fmt.Sprintf("<b>Фамилия:</b> %s\n", user.LastName)
+		info += tr.T("info.last_name", i18n.Args{"name": user.LastName})
 	}
 
 	if user.UserName != "" {
-		info += fmt.Sprintf("<b>Username:</b> @%s\n", user.UserName)
+		info += tr.T("info.username", i18n.Args{"username": user.UserName})
 	}
 
-	info += fmt.Sprintf("<b>Язык:</b> %s\n", user.LanguageCode)
-	info += fmt.Sprintf("<b>Бот:</b> %v\n", user.IsBot)
+	info += tr.T("info.language", i18n.Args{"language": user.LanguageCode})
+	info += tr.T("info.is_bot", i18n.Args{"is_bot": user.IsBot})
 
 	reply := tgbotapi.NewMessage(chatID, info)
 	reply.ParseMode = tgbotapi.ModeHTML
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"telegram-bot/internal/admins"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// AdminsHandler обрабатывает команду /admins — список владельцев и назначенных администраторов
+type AdminsHandler struct {
+	admins   *admins.Service
+	users    repository.UserRepository
+	settings *settings.Service
+	loc      *i18n.Localizer
+}
+
+// NewAdminsHandler создаёт новый обработчик команды /admins
+func NewAdminsHandler(admins *admins.Service, users repository.UserRepository, settings *settings.Service, loc *i18n.Localizer) *AdminsHandler {
+	return &AdminsHandler{
+		admins:   admins,
+		users:    users,
+		settings: settings,
+		loc:      loc,
+	}
+}
+
+// Command возвращает команду
+func (h *AdminsHandler) Command() string {
+	return "admins"
+}
+
+// Handle показывает владельцев и назначенных администраторов
+func (h *AdminsHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	ctx := context.Background()
+	location := h.settings.Location(msg.From.ID)
+
+	var text strings.Builder
+	text.WriteString(tr.T("admins.title"))
+	text.WriteString("\n\n" + tr.T("admins.owners"))
+	for _, ownerID := range h.admins.Owners() {
+		text.WriteString("\n• " + userLabel(ctx, h.users, ownerID))
+	}
+
+	text.WriteString("\n\n" + tr.T("admins.granted"))
+	granted := h.admins.List()
+	if len(granted) == 0 {
+		text.WriteString("\n" + tr.T("admins.none"))
+	}
+	for _, admin := range granted {
+		text.WriteString("\n• " + tr.T("admins.item", i18n.Args{
+			"user":  userLabel(ctx, h.users, admin.UserID),
+			"owner": userLabel(ctx, h.users, admin.GrantedBy),
+			"date":  tr.Date(admin.GrantedAt.In(location)),
+		}))
+	}
+
+	if h.admins.IsOwner(msg.From.ID) {
+		text.WriteString("\n\n" + tr.T("admins.hint"))
+	}
+	return sendHTML(bot, msg.Chat.ID, text.String(), nil)
+}
+
+// GrantHandler обрабатывает команду /grant <ID> (или ответом на сообщение) —
+// владелец назначает пользователя администратором
+type GrantHandler struct {
+	admins *admins.Service
+	users  repository.UserRepository
+	loc    *i18n.Localizer
+}
+
+// NewGrantHandler создаёт новый обработчик команды /grant
+func NewGrantHandler(admins *admins.Service, users repository.UserRepository, loc *i18n.Localizer) *GrantHandler {
+	return &GrantHandler{
+		admins: admins,
+		users:  users,
+		loc:    loc,
+	}
+}
+
+// Command возвращает команду
+func (h *GrantHandler) Command() string {
+	return "grant"
+}
+
+// Handle выдаёт пользователю права администратора
+func (h *GrantHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !requireOwner(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено
+	}
+
+	userID, _, ok := commandTarget(msg)
+	if !ok {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.grant_usage"), nil)
+	}
+
+	ctx := context.Background()
+	args := i18n.Args{"user": userLabel(ctx, h.users, userID)}
+	if h.admins.IsOwner(userID) {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.is_owner", args), nil)
+	}
+
+	granted, err := h.admins.Grant(ctx, userID, msg.From.ID)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.already", args), nil)
+	}
+	return sendHTML(bot, msg.Chat.ID, tr.T("admins.grant_done", args), nil)
+}
+
+// RevokeHandler обрабатывает команду /revoke <ID> (или ответом на сообщение) —
+// владелец отзывает права администратора
+type RevokeHandler struct {
+	admins *admins.Service
+	users  repository.UserRepository
+	loc    *i18n.Localizer
+}
+
+// NewRevokeHandler создаёт новый обработчик команды /revoke
+func NewRevokeHandler(admins *admins.Service, users repository.UserRepository, loc *i18n.Localizer) *RevokeHandler {
+	return &RevokeHandler{
+		admins: admins,
+		users:  users,
+		loc:    loc,
+	}
+}
+
+// Command возвращает команду
+func (h *RevokeHandler) Command() string {
+	return "revoke"
+}
+
+// Handle отзывает права администратора
+func (h *RevokeHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !requireOwner(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено
+	}
+
+	userID, _, ok := commandTarget(msg)
+	if !ok {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.revoke_usage"), nil)
+	}
+
+	ctx := context.Background()
+	args := i18n.Args{"user": userLabel(ctx, h.users, userID)}
+	if h.admins.IsOwner(userID) {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.is_owner", args), nil)
+	}
+
+	revoked, err := h.admins.Revoke(ctx, userID, msg.From.ID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return sendHTML(bot, msg.Chat.ID, tr.T("admins.not_admin", args), nil)
+	}
+	return sendHTML(bot, msg.Chat.ID, tr.T("admins.revoke_done", args), nil)
+}
+
+// requireOwner пропускает только владельцев бота. Остальным администраторам
+// объясняет, что права выдают владельцы, прочим отвечает как RequireAdmin
+func requireOwner(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, admins *admins.Service, tr *i18n.Translator) bool {
+	if !middleware.RequireAdmin(bot, msg, admins, tr) {
+		return false
+	}
+	if !admins.IsOwner(msg.From.ID) {
+		sendHTML(bot, msg.Chat.ID, tr.T("admins.owner_only"), nil)
+		return false
+	}
+	return true
+}
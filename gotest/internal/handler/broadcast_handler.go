@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/broadcast"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/session"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	broadcastPrefix = "broadcast"      // Префикс callback-данных кнопки остановки: "broadcast:cancel:<id>"
+	broadcastFlow   = "broadcast"      // Сценарий подготовки рассылки
+	broadcastTTL    = 10 * time.Minute // Сколько действуют кнопки подтверждения рассылки
+	stepCompose     = "compose"        // Шаг: ждём сообщение для рассылки
+
+	broadcastAudienceKey = "audience" // Ключ аудитории (JSON) в данных сессии
+)
+
+// broadcastTitles — заголовок сообщения с ходом рассылки для каждого её состояния
+var broadcastTitles = []struct {
+	status string
+	key    string
+}{
+	{status: domain.BroadcastRunning, key: "broadcast.progress.running"},
+	{status: domain.BroadcastCompleted, key: "broadcast.progress.completed"},
+	{status: domain.BroadcastCancelled, key: "broadcast.progress.cancelled"},
+}
+
+// BroadcastHandler обрабатывает команду /broadcast [сегмент | условия] — рассылку сообщения.
+// Администратор выбирает аудиторию, присылает сообщение любого типа, видит, как его
+// получат пользователи, подтверждает отправку и следит за её ходом в отдельном сообщении
+// с кнопкой остановки
+type BroadcastHandler struct {
+	broadcasts *broadcast.Service
+	settings   *settings.Service
+	sessions   *session.Store
+	confirm    *ConfirmManager
+	admins     middleware.AdminChecker
+	loc        *i18n.Localizer
+}
+
+// NewBroadcastHandler создаёт новый обработчик команды /broadcast
+func NewBroadcastHandler(broadcasts *broadcast.Service, settings *settings.Service, sessions *session.Store, confirm *ConfirmManager, admins middleware.AdminChecker, loc *i18n.Localizer) *BroadcastHandler {
+	return &BroadcastHandler{
+		broadcasts: broadcasts,
+		settings:   settings,
+		sessions:   sessions,
+		confirm:    confirm,
+		admins:     admins,
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *BroadcastHandler) Command() string {
+	return "broadcast"
+}
+
+// Name возвращает имя сценария
+func (h *BroadcastHandler) Name() string {
+	return broadcastFlow
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *BroadcastHandler) Prefix() string {
+	return broadcastPrefix
+}
+
+// Handle начинает подготовку рассылки: определяет аудиторию и просит прислать сообщение
+func (h *BroadcastHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	ctx := context.Background()
+	location := h.settings.Location(msg.From.ID)
+	audience, err := h.broadcasts.Audience(ctx, msg.CommandArguments(), location)
+	if text, ok := audienceErrorText(tr, err); ok {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+		reply.ParseMode = tgbotapi.ModeHTML
+		_, err := bot.Send(reply)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	recipients, err := h.broadcasts.Recipients(ctx, audience)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		_, err := bot.Send(tgbotapi.NewMessage(msg.Chat.ID, tr.T("broadcast.no_recipients")))
+		return err
+	}
+
+	// Аудитория хранится в сессии до тех пор, пока администратор не пришлёт сообщение
+	encoded, err := json.Marshal(audience)
+	if err != nil {
+		return err
+	}
+	sess := h.sessions.Start(msg.From.ID, broadcastFlow, stepCompose)
+	sess.Data[broadcastAudienceKey] = string(encoded)
+	h.sessions.Save(msg.From.ID, sess)
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("broadcast.compose", i18n.Args{
+		"audience":   describeAudience(tr, audience, location),
+		"recipients": tr.N("broadcast.recipients", int64(len(recipients))),
+	}))
+	reply.ParseMode = tgbotapi.ModeHTML
+	_, err = bot.Send(reply)
+	return err
+}
+
+// HandleStep принимает сообщение для рассылки, показывает его и спрашивает подтверждение
+func (h *BroadcastHandler) HandleStep(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, sess session.Session) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		h.sessions.Reset(msg.From.ID)
+		return nil
+	}
+
+	var audience domain.Audience
+	if err := json.Unmarshal([]byte(sess.Data[broadcastAudienceKey]), &audience); err != nil {
+		h.sessions.Reset(msg.From.ID)
+		return fmt.Errorf("ошибка чтения аудитории рассылки из сессии: %w", err)
+	}
+
+	// Пересланный пост рассылается пересылкой, чтобы была видна подпись источника,
+	// остальные сообщения — копией от имени бота
+	draft := domain.Broadcast{
+		AuthorID:        msg.From.ID,
+		SourceChatID:    msg.Chat.ID,
+		SourceMessageID: msg.MessageID,
+		Forward:         msg.ForwardDate != 0,
+		Audience:        audience,
+		ProgressChatID:  msg.Chat.ID,
+	}
+
+	// Пока администратор готовил сообщение, аудитория могла измениться
+	ctx := context.Background()
+	recipients, err := h.broadcasts.Recipients(ctx, audience)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		h.sessions.Reset(msg.From.ID)
+		reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("broadcast.no_recipients"))
+		_, err := bot.Send(reply)
+		return err
+	}
+
+	// Если Telegram не умеет копировать такое сообщение, ждём другое
+	if err := previewBroadcast(bot, msg, tr, draft); err != nil {
+		return err
+	}
+	h.sessions.Reset(msg.From.ID)
+
+	question := tr.N("broadcast.confirm", int64(len(recipients)))
+	return h.confirm.Ask(bot, msg, question, broadcastTTL, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) (string, error) {
+		tr := h.loc.For(callback.From)
+
+		started := draft
+		err := h.broadcasts.Start(context.Background(), &started)
+		if errors.Is(err, broadcast.ErrNoRecipients) {
+			return tr.T("broadcast.no_recipients"), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return tr.T("broadcast.started", i18n.Args{"id": started.ID}), nil
+	})
+}
+
+// HandleCallback обрабатывает кнопку остановки рассылки
+func (h *BroadcastHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	tr := h.loc.For(callback.From)
+	if !h.admins.IsAdmin(callback.From.ID) {
+		return AnswerCallbackAlert(bot, callback, tr.T("auth.forbidden"))
+	}
+
+	// Данные кнопки: "broadcast:cancel:<id>"
+	payload := strings.TrimPrefix(callback.Data, broadcastPrefix+":cancel:")
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return fmt.Errorf("неверные данные кнопки рассылки: %q", callback.Data)
+	}
+
+	cancelled, err := h.broadcasts.Cancel(context.Background(), id)
+	if err != nil {
+		_ = AnswerCallback(bot, callback, tr.T("broadcast.cancel_failed"))
+		return err
+	}
+
+	// Сообщение с ходом рассылки обновит сервис рассылок
+	text := tr.T("broadcast.cancelled")
+	if !cancelled {
+		text = tr.T("broadcast.already_finished")
+	}
+	return AnswerCallback(bot, callback, text)
+}
+
+// previewBroadcast показывает администратору сообщение рассылки так, как его получат пользователи.
+// Если Telegram не умеет копировать такое сообщение, просит прислать другое и возвращает ошибку
+func previewBroadcast(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, draft domain.Broadcast) error {
+	if _, err := bot.Send(tgbotapi.NewMessage(msg.Chat.ID, tr.T("broadcast.preview"))); err != nil {
+		return err
+	}
+	if _, err := bot.Request(broadcast.Message(draft, msg.Chat.ID)); err != nil {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("broadcast.unsupported"))
+		if _, sendErr := bot.Send(reply); sendErr != nil {
+			return sendErr
+		}
+		return fmt.Errorf("ошибка предпросмотра рассылки: %w", err)
+	}
+	return nil
+}
+
+// BroadcastReporter показывает автору рассылки её ход в сообщении с кнопкой остановки
+// (реализует broadcast.Reporter)
+type BroadcastReporter struct {
+	loc *i18n.Localizer
+}
+
+// NewBroadcastReporter создаёт новый показ хода рассылок
+func NewBroadcastReporter(loc *i18n.Localizer) *BroadcastReporter {
+	return &BroadcastReporter{
+		loc: loc,
+	}
+}
+
+// Report показывает ход рассылки её автору
+func (r *BroadcastReporter) Report(bot *tgbotapi.BotAPI, b *domain.Broadcast, progress repository.BroadcastProgress) (int, error) {
+	tr := r.loc.For(&tgbotapi.User{ID: b.AuthorID})
+
+	var text strings.Builder
+	for _, title := range broadcastTitles {
+		if title.status == b.Status {
+			text.WriteString(tr.T(title.key, i18n.Args{"id": b.ID}) + "\n\n")
+		}
+	}
+
+	percent := 100.0
+	if progress.Total > 0 {
+		percent = float64(progress.Done()) * 100 / float64(progress.Total)
+	}
+	text.WriteString(tr.T("broadcast.progress.done", i18n.Args{
+		"done":    tr.Number(int64(progress.Done())),
+		"total":   tr.Number(int64(progress.Total)),
+		"percent": tr.Float(percent, 0),
+	}) + "\n\n")
+	text.WriteString(tr.T("broadcast.progress.stats", i18n.Args{
+		"sent":    tr.Number(int64(progress.Sent)),
+		"blocked": tr.Number(int64(progress.Blocked)),
+		"failed":  tr.Number(int64(progress.Failed)),
+		"quiet":   tr.Number(int64(progress.Quiet)),
+	}))
+
+	// Пока рассылка идёт, её можно остановить
+	var markup *tgbotapi.InlineKeyboardMarkup
+	if !b.IsFinished() {
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(tr.T("broadcast.button.cancel"), fmt.Sprintf("%s:cancel:%d", broadcastPrefix, b.ID)),
+		))
+		markup = &keyboard
+	}
+
+	if b.ProgressMessageID != 0 {
+		edit := tgbotapi.EditMessageTextConfig{
+			BaseEdit: tgbotapi.BaseEdit{
+				ChatID:      b.ProgressChatID,
+				MessageID:   b.ProgressMessageID,
+				ReplyMarkup: markup,
+			},
+			Text:      text.String(),
+			ParseMode: tgbotapi.ModeHTML,
+		}
+		_, err := bot.Request(edit)
+		switch {
+		case err == nil, isMessageNotModified(err):
+			return b.ProgressMessageID, nil
+		case !isMessageGone(err):
+			return b.ProgressMessageID, err
+		}
+		// Сообщение удалено — показываем ход рассылки в новом
+	}
+
+	reply := tgbotapi.NewMessage(b.ProgressChatID, text.String())
+	reply.ParseMode = tgbotapi.ModeHTML
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+	sent, err := bot.Send(reply)
+	if err != nil {
+		return b.ProgressMessageID, err
+	}
+	return sent.MessageID, nil
+}
@@ -1,44 +1,79 @@
 package handler
 
 import (
-	"fmt"
+	"log"
+	"strings"
+
+	"telegram-bot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// CallbackRoute — обработчик callback-запросов с определённым префиксом
+// Данные таких кнопок имеют вид "префикс:полезная_нагрузка"
+type CallbackRoute interface {
+	Prefix() string // Префикс данных, по которому выбирается обработчик
+	HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error
+}
+
 // CallbackHandler обрабатывает callback-запросы от инлайн-кнопок
-type CallbackHandler struct{}
+type CallbackHandler struct {
+	routes   map[string]CallbackRoute // Карта: префикс -> обработчик
+	deleteMe *DeleteMeHandler         // Кнопки delete_profile_yes/no под старыми приветствиями /start
+	loc      *i18n.Localizer
+}
 
 // NewCallbackHandler создаёт новый обработчик callback-запросов
-func NewCallbackHandler() *CallbackHandler {
-	return &CallbackHandler{}
+func NewCallbackHandler(deleteMe *DeleteMeHandler, loc *i18n.Localizer) *CallbackHandler {
+	return &CallbackHandler{
+		routes:   make(map[string]CallbackRoute),
+		deleteMe: deleteMe,
+		loc:      loc,
+	}
+}
+
+// Register регистрирует обработчик для callback-данных с заданным префиксом
+func (h *CallbackHandler) Register(route CallbackRoute) {
+	prefix := route.Prefix()
+	h.routes[prefix] = route
+	log.Printf("Зарегистрирован обработчик callback-запросов с префиксом %s:", prefix)
 }
 
 // Handle обрабатывает callback-запрос
 func (h *CallbackHandler) Handle(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
-	chatID := callback.Message.Chat.ID
-	callbackData := callback.Data
-
-	// Отвечаем на callback-запрос (убираем индикатор загрузки)
-	callbackConfig := tgbotapi.NewCallback(callback.ID, "")
-	if _, err := bot.Request(callbackConfig); err != nil {
-		return fmt.Errorf("ошибка ответа на callback: %w", err)
+	// Если для префикса данных есть свой обработчик — передаём запрос ему.
+	// Такой обработчик сам отвечает на callback-запрос
+	if prefix, _, found := strings.Cut(callback.Data, ":"); found {
+		if route, exists := h.routes[prefix]; exists {
+			return route.HandleCallback(bot, callback)
+		}
 	}
 
 	// Обрабатываем различные типы callback-данных
 	var replyText string
-	switch callbackData {
-	case "lang_ru":
-		replyText = "✅ Выбран язык: Русский"
-	case "lang_en":
-		replyText = "✅ Выбран язык: English"
+	switch callback.Data {
+	case "lang_ru", "lang_en":
+		lang := strings.TrimPrefix(callback.Data, "lang_")
+		if err := h.loc.SetLanguage(callback.From.ID, lang); err != nil {
+			log.Printf("Ошибка сохранения языка пользователя %d: %v", callback.From.ID, err)
+		}
+
+		// Отвечаем уже на выбранном языке
+		tr := h.loc.Bundle().Translator(lang)
+		replyText = tr.T("callback.language_selected", i18n.Args{"language": tr.T("language.name")})
+	case deleteProfilePrefix + "_yes", deleteProfilePrefix + "_no":
+		// Сам отвечает на callback-запрос и меняет сообщение
+		return h.deleteMe.HandleDeleteProfile(bot, callback)
 	default:
-		replyText = "Неизвестная команда"
+		// Сообщение не трогаем, только показываем подсказку
+		return AnswerCallback(bot, callback, h.loc.For(callback.From).T("callback.unknown"))
 	}
 
-	// Отправляем ответ пользователю
-	reply := tgbotapi.NewMessage(chatID, replyText)
-	_, err := bot.Send(reply)
-	return err
-}
+	// Отвечаем на callback-запрос (убираем индикатор загрузки)
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
 
+	// Показываем результат в том же сообщении вместо отправки нового
+	return EditCallbackMessage(bot, callback, replyText, nil)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Тексты ошибок Telegram, которые при редактировании сообщений не считаются ошибками
+const (
+	errMessageNotModified  = "message is not modified"   // Текст и клавиатура не изменились
+	errMessageNotFound     = "message to edit not found" // Сообщение уже удалено
+	errMessageCantBeEdited = "message can't be edited"   // Сообщение слишком старое или чужое
+)
+
+// AnswerCallback отвечает на callback-запрос всплывающей подсказкой (toast).
+// Пустой текст просто убирает индикатор загрузки на кнопке
+func AnswerCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, text string) error {
+	if _, err := bot.Request(tgbotapi.NewCallback(callback.ID, text)); err != nil {
+		return fmt.Errorf("ошибка ответа на callback: %w", err)
+	}
+	return nil
+}
+
+// AnswerCallbackAlert отвечает на callback-запрос окном, которое нужно закрыть кнопкой "OK"
+func AnswerCallbackAlert(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, text string) error {
+	if _, err := bot.Request(tgbotapi.NewCallbackWithAlert(callback.ID, text)); err != nil {
+		return fmt.Errorf("ошибка ответа на callback: %w", err)
+	}
+	return nil
+}
+
+// EditCallbackMessage заменяет текст и клавиатуру сообщения, на котором нажата кнопка.
+// markup == nil убирает инлайн-клавиатуру
+func EditCallbackMessage(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, text string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	return editCallbackText(bot, callback, text, "", markup)
+}
+
+// EditCallbackMessageHTML работает как EditCallbackMessage, но разбирает текст как HTML
+func EditCallbackMessageHTML(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, text string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	return editCallbackText(bot, callback, text, tgbotapi.ModeHTML, markup)
+}
+
+// EditCallbackKeyboard заменяет только клавиатуру сообщения, на котором нажата кнопка.
+// markup == nil убирает инлайн-клавиатуру
+func EditCallbackKeyboard(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, markup *tgbotapi.InlineKeyboardMarkup) error {
+	edit := tgbotapi.EditMessageReplyMarkupConfig{
+		BaseEdit: callbackEditTarget(callback, markup),
+	}
+
+	_, err := bot.Request(edit)
+	if isMessageNotModified(err) || isMessageGone(err) {
+		// Клавиатура уже такая или её больше негде показать
+		return nil
+	}
+	return err
+}
+
+// editCallbackText редактирует текст сообщения. Если сообщение уже нельзя
+// отредактировать, результат отправляется новым сообщением в тот же чат
+func editCallbackText(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, text, parseMode string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	edit := tgbotapi.EditMessageTextConfig{
+		BaseEdit:  callbackEditTarget(callback, markup),
+		Text:      text,
+		ParseMode: parseMode,
+	}
+
+	// Request вместо Send: для инлайн-сообщений Telegram возвращает true, а не сообщение
+	_, err := bot.Request(edit)
+	switch {
+	case err == nil, isMessageNotModified(err):
+		// Повторное нажатие на ту же кнопку — показывать нечего
+		return nil
+	case isMessageGone(err) && callback.Message != nil:
+		reply := tgbotapi.NewMessage(callback.Message.Chat.ID, text)
+		reply.ParseMode = parseMode
+		if markup != nil {
+			reply.ReplyMarkup = *markup
+		}
+		_, err = bot.Send(reply)
+		return err
+	default:
+		return err
+	}
+}
+
+// callbackEditTarget определяет, какое сообщение редактировать:
+// обычное сообщение в чате или сообщение, отправленное через инлайн-режим
+func callbackEditTarget(callback *tgbotapi.CallbackQuery, markup *tgbotapi.InlineKeyboardMarkup) tgbotapi.BaseEdit {
+	target := tgbotapi.BaseEdit{
+		InlineMessageID: callback.InlineMessageID,
+		ReplyMarkup:     markup,
+	}
+	if callback.Message != nil {
+		target.ChatID = callback.Message.Chat.ID
+		target.MessageID = callback.Message.MessageID
+	}
+	return target
+}
+
+// isMessageNotModified сообщает, что новое содержимое совпадает со старым
+func isMessageNotModified(err error) bool {
+	return hasAPIError(err, errMessageNotModified)
+}
+
+// isMessageGone сообщает, что сообщение удалено или больше не может быть изменено
+func isMessageGone(err error) bool {
+	return hasAPIError(err, errMessageNotFound) || hasAPIError(err, errMessageCantBeEdited)
+}
+
+// hasAPIError проверяет, что Telegram вернул ошибку с указанным текстом
+func hasAPIError(err error, text string) bool {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return strings.Contains(apiErr.Message, text)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/session"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// CancelHandler обрабатывает команду /cancel и кнопку "❌ Отмена"
+type CancelHandler struct {
+	sessions *session.Store
+	loc      *i18n.Localizer
+}
+
+// NewCancelHandler создаёт новый обработчик команды /cancel
+func NewCancelHandler(sessions *session.Store, loc *i18n.Localizer) *CancelHandler {
+	return &CancelHandler{
+		sessions: sessions,
+		loc:      loc,
+	}
+}
+
+// Command возвращает команду
+func (h *CancelHandler) Command() string {
+	return "cancel"
+}
+
+// Handle завершает активный сценарий и убирает клавиатуру
+func (h *CancelHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+
+	text := tr.T("cancel.nothing")
+	if cancelled := h.sessions.Reset(msg.From.ID); cancelled.Active() {
+		text = tr.T("cancel.done")
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ReplyMarkup = tgbotapi.NewRemoveKeyboard(false)
+	_, err := bot.Send(reply)
+	return err
+}
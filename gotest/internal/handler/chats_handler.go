@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// chatsPrefix — префикс callback-данных списка чатов
+const chatsPrefix = "chats"
+
+// chatsPageSize — количество чатов на странице
+const chatsPageSize = 10
+
+// ChatsHandler обрабатывает команду /chats — список групп и каналов бота (только для администраторов)
+type ChatsHandler struct {
+	admins    middleware.AdminChecker
+	paginator *Paginator
+	loc       *i18n.Localizer
+}
+
+// NewChatsHandler создаёт новый обработчик команды /chats
+func NewChatsHandler(chats repository.ChatRepository, admins middleware.AdminChecker, loc *i18n.Localizer) *ChatsHandler {
+	return &ChatsHandler{
+		admins:    admins,
+		paginator: NewPaginator(chatsPrefix, "chats.title", chatsPageSize, chatPageSource{chats: chats}, loc),
+		loc:       loc,
+	}
+}
+
+// Command возвращает команду
+func (h *ChatsHandler) Command() string {
+	return "chats"
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *ChatsHandler) Prefix() string {
+	return h.paginator.Prefix()
+}
+
+// Handle обрабатывает команду /chats
+func (h *ChatsHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	if !middleware.RequireAdmin(bot, msg, h.admins, h.loc.For(msg.From)) {
+		return nil // Сообщение уже отправлено middleware
+	}
+	return h.paginator.Send(bot, msg)
+}
+
+// HandleCallback листает список чатов
+func (h *ChatsHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	if !h.admins.IsAdmin(callback.From.ID) {
+		return AnswerCallbackAlert(bot, callback, h.loc.For(callback.From).T("auth.forbidden"))
+	}
+	return h.paginator.HandleCallback(bot, callback)
+}
+
+// chatPageSource — источник постраничного списка поверх репозитория чатов
+type chatPageSource struct {
+	chats repository.ChatRepository
+}
+
+// Count возвращает количество чатов
+func (s chatPageSource) Count() (int, error) {
+	return s.chats.Count(context.Background(), repository.ChatFilter{})
+}
+
+// Items возвращает чаты одной страницы
+func (s chatPageSource) Items(offset, limit int) ([]PageItem, error) {
+	chats, err := s.chats.List(context.Background(), repository.ChatFilter{}, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]PageItem, 0, len(chats))
+	for _, chat := range chats {
+		items = append(items, PageItem{Text: chatLine(chat)})
+	}
+	return items, nil
+}
+
+// chatLine описывает чат одной строкой:
+// "📢 <b>Новости</b> <code>-100123</code> · administrator ✍️"
+func chatLine(chat domain.Chat) string {
+	icon := "👥"
+	if chat.Type == domain.ChatTypeChannel {
+		icon = "📢"
+	}
+	if !chat.IsActive() {
+		icon = "🚫"
+	}
+
+	title := html.EscapeString(chat.Title)
+	if chat.Username != "" {
+		title += " @" + chat.Username
+	}
+
+	line := fmt.Sprintf("%s <b>%s</b> <code>%d</code> · %s", icon, title, chat.ID, chat.BotStatus)
+	if chat.CanSendMessages {
+		line += " ✍️"
+	}
+	return line
+}
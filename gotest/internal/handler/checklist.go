@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// checklistPrefix — префикс callback-данных списка с множественным выбором
+const checklistPrefix = "chk"
+
+// checklistTTL — сколько действуют кнопки списка
+const checklistTTL = 15 * time.Minute
+
+// ChecklistDoneFunc получает ключи выбранных вариантов (в порядке списка).
+// Возвращает текст в HTML, которым заменяется сообщение со списком
+type ChecklistDoneFunc func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, selected []string) (string, error)
+
+// pendingChecklist — список, ожидающий выбора пользователя
+type pendingChecklist struct {
+	options  []keyboard.ChecklistOption // Варианты
+	selected map[string]bool            // Отмеченные варианты
+	done     ChecklistDoneFunc          // Кому передать результат
+}
+
+// Checklist показывает список вариантов с отметками ✅ и возвращает выбранные.
+// Текущий выбор хранится вместе со списком, а не в сессии, поэтому сценарии,
+// начатые до или после открытия списка, его не сбрасывают
+type Checklist struct {
+	pending *pendingStore[pendingChecklist]
+	loc     *i18n.Localizer
+}
+
+// NewChecklist создаёт новый компонент множественного выбора
+func NewChecklist(loc *i18n.Localizer) *Checklist {
+	return &Checklist{
+		pending: newPendingStore[pendingChecklist](),
+		loc:     loc,
+	}
+}
+
+// Prefix возвращает префикс callback-данных
+func (c *Checklist) Prefix() string {
+	return checklistPrefix
+}
+
+// Ask отправляет список вариантов; selected — варианты, отмеченные изначально.
+// Отмечать варианты может только автор msg. Question — текст в HTML
+func (c *Checklist) Ask(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, question string, options []keyboard.ChecklistOption, selected []string, done ChecklistDoneFunc) error {
+	// Самые длинные данные кнопки: "chk:<uuid>:t:<ключ>"
+	overhead := len(checklistPrefix) + len(":") + pendingIDLength + len(":"+keyboard.ChecklistOpToggle+":")
+	for _, option := range options {
+		if overhead+len(option.Key) > keyboard.MaxCallbackDataBytes {
+			return fmt.Errorf("ключ варианта %q не помещается в callback-данные (%d байт)", option.Key, keyboard.MaxCallbackDataBytes)
+		}
+	}
+
+	id := c.pending.add(msg.From.ID, checklistTTL, pendingChecklist{
+		options:  options,
+		selected: selectionSet(selected),
+		done:     done,
+	})
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, question)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = keyboard.NewChecklistKeyboard(checklistPrefix+":"+id, options, selectionSet(selected), c.loc.For(msg.From).T("button.done"))
+	if _, err := bot.Send(reply); err != nil {
+		c.pending.remove(id)
+		return err
+	}
+
+	return nil
+}
+
+// HandleCallback обрабатывает отметку варианта и кнопку "Готово"
+func (c *Checklist) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	// Данные кнопки: "chk:<id>:t:<ключ>" или "chk:<id>:done"
+	parts := strings.SplitN(strings.TrimPrefix(callback.Data, checklistPrefix+":"), ":", 3)
+	if len(parts) < 2 {
+		return fmt.Errorf("неверные данные списка: %q", callback.Data)
+	}
+	id, op := parts[0], parts[1]
+	userID := callback.From.ID
+	tr := c.loc.For(callback.From)
+
+	entry, exists := c.pending.get(id)
+	switch {
+	case !exists || entry.expired(time.Now()):
+		c.pending.remove(id)
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackMessage(bot, callback, tr.T("checklist.expired"), nil)
+	case entry.userID != userID:
+		return AnswerCallbackAlert(bot, callback, tr.T("checklist.foreign"))
+	}
+
+	options := entry.value.options
+	if op == keyboard.ChecklistOpDone {
+		return c.finish(bot, callback, id)
+	}
+
+	if op != keyboard.ChecklistOpToggle || len(parts) < 3 {
+		return AnswerCallback(bot, callback, "")
+	}
+
+	key := parts[2]
+	known := slices.ContainsFunc(options, func(option keyboard.ChecklistOption) bool {
+		return option.Key == key
+	})
+	if !known {
+		return AnswerCallback(bot, callback, "")
+	}
+
+	// Переключаем отметку в копии выбора и перерисовываем клавиатуру в том же сообщении
+	selected := maps.Clone(entry.value.selected)
+	if selected[key] {
+		delete(selected, key)
+	} else {
+		selected[key] = true
+	}
+	entry.value.selected = selected
+	c.pending.update(id, entry.value)
+
+	markup := keyboard.NewChecklistKeyboard(checklistPrefix+":"+id, options, selected, tr.T("button.done"))
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	return EditCallbackKeyboard(bot, callback, &markup)
+}
+
+// finish забирает список и передаёт выбранные варианты обработчику
+func (c *Checklist) finish(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, id string) error {
+	entry, exists, foreign := c.pending.take(id, callback.From.ID)
+	if !exists || foreign {
+		// Выбор уже завершён параллельным нажатием
+		return AnswerCallback(bot, callback, "")
+	}
+	selected := entry.value.selected
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+
+	// Возвращаем ключи в порядке вариантов, а не в порядке нажатий
+	keys := make([]string, 0, len(selected))
+	for _, option := range entry.value.options {
+		if selected[option.Key] {
+			keys = append(keys, option.Key)
+		}
+	}
+
+	text, err := entry.value.done(bot, callback, keys)
+	if err != nil {
+		text = c.loc.For(callback.From).T("checklist.failed")
+	}
+
+	if editErr := EditCallbackMessageHTML(bot, callback, text, nil); editErr != nil {
+		log.Printf("Ошибка редактирования сообщения со списком: %v", editErr)
+	}
+	return err
+}
+
+// selectionSet превращает список ключей во множество
+func selectionSet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// confirmPrefix — префикс callback-данных кнопок подтверждения
+const confirmPrefix = "confirm"
+
+// ConfirmAction — действие, которое выполняется после нажатия "Да".
+// Возвращает текст, которым заменяется сообщение с вопросом
+type ConfirmAction func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) (string, error)
+
+// ConfirmManager связывает кнопки "Да"/"Нет" с действием, которое они подтверждают
+type ConfirmManager struct {
+	pending *pendingStore[ConfirmAction] // Действия, ожидающие подтверждения
+	loc     *i18n.Localizer
+}
+
+// NewConfirmManager создаёт новый менеджер подтверждений
+func NewConfirmManager(loc *i18n.Localizer) *ConfirmManager {
+	return &ConfirmManager{
+		pending: newPendingStore[ConfirmAction](),
+		loc:     loc,
+	}
+}
+
+// Prefix возвращает префикс callback-данных
+func (m *ConfirmManager) Prefix() string {
+	return confirmPrefix
+}
+
+// Ask отправляет вопрос с кнопками "Да" и "Нет".
+// Нажать кнопку может только автор msg, и только в течение ttl.
+// Действие выполняется не более одного раза
+func (m *ConfirmManager) Ask(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, question string, ttl time.Duration, action ConfirmAction) error {
+	id := m.pending.add(msg.From.ID, ttl, action)
+	tr := m.loc.For(msg.From)
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, question)
+	reply.ReplyMarkup = keyboard.NewConfirmKeyboardWithLabels(confirmPrefix+":"+id, tr.T("button.yes"), tr.T("button.no"))
+	if _, err := bot.Send(reply); err != nil {
+		m.pending.remove(id)
+		return err
+	}
+
+	return nil
+}
+
+// HandleCallback обрабатывает нажатие на кнопку подтверждения
+func (m *ConfirmManager) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	// Данные кнопки: "confirm:<id>_yes" или "confirm:<id>_no"
+	payload := strings.TrimPrefix(callback.Data, confirmPrefix+":")
+	separator := strings.LastIndex(payload, "_")
+	if separator < 0 {
+		return fmt.Errorf("неверные данные подтверждения: %q", callback.Data)
+	}
+	id, answer := payload[:separator], payload[separator+1:]
+	tr := m.loc.For(callback.From)
+
+	// Забираем действие под блокировкой — так второе нажатие
+	// (или нажатие на соседнюю кнопку) его уже не найдёт
+	pending, exists, foreign := m.pending.take(id, callback.From.ID)
+	if foreign {
+		return AnswerCallbackAlert(bot, callback, tr.T("confirm.foreign"))
+	}
+
+	// Отвечаем на callback-запрос (убираем индикатор загрузки)
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+
+	var (
+		text      string
+		actionErr error
+	)
+	switch {
+	case !exists || pending.expired(time.Now()):
+		text = tr.T("confirm.expired")
+	case answer == "yes":
+		text, actionErr = pending.value(bot, callback)
+		if actionErr != nil {
+			text = tr.T("confirm.failed")
+		}
+	default:
+		text = tr.T("confirm.cancelled")
+	}
+
+	// Заменяем вопрос результатом и убираем кнопки
+	if err := EditCallbackMessage(bot, callback, text, nil); err != nil {
+		log.Printf("Ошибка редактирования сообщения с подтверждением: %v", err)
+	}
+
+	return actionErr
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// datePickerPrefix — префикс callback-данных календаря и выбора времени
+const datePickerPrefix = "cal"
+
+// defaultPickTTL — сколько действует календарь, если срок не задан
+const defaultPickTTL = 15 * time.Minute
+
+// DatePickedFunc получает выбранный момент времени в часовом поясе пользователя.
+// Возвращает текст, которым заменяется сообщение с календарём
+type DatePickedFunc func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, value time.Time) (string, error)
+
+// DatePickerOptions — настройки календаря
+type DatePickerOptions struct {
+	Location   *time.Location // Часовой пояс (nil — часовой пояс из настроек пользователя)
+	Locale     string         // Язык названий месяцев и дней недели (по умолчанию — язык пользователя)
+	Min        time.Time      // Самый ранний доступный момент (с WithTime проверяется и время)
+	Max        time.Time      // Самый поздний доступный момент
+	WithTime   bool           // После выбора дня показать выбор времени
+	MinuteStep int            // Шаг минут в выборе времени (по умолчанию 5)
+	TTL        time.Duration  // Сколько действуют кнопки (по умолчанию 15 минут)
+}
+
+// pendingPick — запрос даты, ожидающий ответа пользователя
+type pendingPick struct {
+	question string // Текст над календарём
+	opts     DatePickerOptions
+	day      time.Time      // Выбранный день (когда показывается выбор времени)
+	done     DatePickedFunc // Кому передать результат
+}
+
+// DatePicker показывает календарь и выбор времени и возвращает результат вызвавшему обработчику
+type DatePicker struct {
+	pending  *pendingStore[pendingPick]
+	settings *settings.Service
+	loc      *i18n.Localizer
+}
+
+// NewDatePicker создаёт новый компонент выбора даты
+func NewDatePicker(settings *settings.Service, loc *i18n.Localizer) *DatePicker {
+	return &DatePicker{
+		pending:  newPendingStore[pendingPick](),
+		settings: settings,
+		loc:      loc,
+	}
+}
+
+// Prefix возвращает префикс callback-данных
+func (p *DatePicker) Prefix() string {
+	return datePickerPrefix
+}
+
+// Ask отправляет календарь. Выбирать дату может только автор msg.
+// После выбора done получает дату (и время, если WithTime) в часовом поясе opts.Location
+func (p *DatePicker) Ask(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, question string, opts DatePickerOptions, done DatePickedFunc) error {
+	if opts.Location == nil {
+		opts.Location = p.settings.Location(msg.From.ID)
+	}
+	if opts.MinuteStep <= 0 {
+		opts.MinuteStep = 5
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultPickTTL
+	}
+	if opts.Locale == "" {
+		opts.Locale = p.loc.Resolve(msg.From)
+	}
+
+	id := p.pending.add(msg.From.ID, opts.TTL, pendingPick{
+		question: question,
+		opts:     opts,
+		done:     done,
+	})
+
+	// Календарь открывается на месяце минимальной даты, если она в будущем
+	month := time.Now().In(opts.Location)
+	if opts.Min.After(month) {
+		month = opts.Min.In(opts.Location)
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, question)
+	reply.ReplyMarkup = keyboard.NewCalendarKeyboard(datePickerPrefix+":"+id, month, p.calendarOptions(opts))
+	if _, err := bot.Send(reply); err != nil {
+		p.pending.remove(id)
+		return err
+	}
+
+	return nil
+}
+
+// HandleCallback обрабатывает нажатия в календаре и выборе времени
+func (p *DatePicker) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	// Данные кнопки: "cal:<id>:<операция>[:<значение>]"
+	parts := strings.SplitN(strings.TrimPrefix(callback.Data, datePickerPrefix+":"), ":", 3)
+	if len(parts) < 2 {
+		return fmt.Errorf("неверные данные календаря: %q", callback.Data)
+	}
+	id, op := parts[0], parts[1]
+	value := ""
+	if len(parts) == 3 {
+		value = parts[2]
+	}
+
+	tr := p.loc.For(callback.From)
+	entry, exists := p.pending.get(id)
+	switch {
+	case !exists || entry.expired(time.Now()):
+		p.pending.remove(id)
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackMessage(bot, callback, tr.T("datepicker.expired"), nil)
+	case entry.userID != callback.From.ID:
+		return AnswerCallbackAlert(bot, callback, tr.T("datepicker.foreign"))
+	}
+
+	pick := entry.value
+	dataPrefix := datePickerPrefix + ":" + id
+
+	switch op {
+	case keyboard.CalendarOpNavigate:
+		month, err := time.ParseInLocation(keyboard.CalendarMonthFormat, value, pick.opts.Location)
+		if err != nil {
+			return AnswerCallback(bot, callback, "")
+		}
+		markup := keyboard.NewCalendarKeyboard(dataPrefix, month, p.calendarOptions(pick.opts))
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackKeyboard(bot, callback, &markup)
+
+	case keyboard.CalendarOpDay:
+		day, err := time.ParseInLocation(keyboard.CalendarDayFormat, value, pick.opts.Location)
+		if err != nil || !p.calendarOptions(pick.opts).Allows(day) {
+			return AnswerCallback(bot, callback, tr.T("datepicker.unavailable"))
+		}
+		if !pick.opts.WithTime {
+			return p.finish(bot, callback, id, day)
+		}
+
+		// Запоминаем день и переходим к выбору времени
+		pick.day = day
+		p.pending.update(id, pick)
+
+		hour, minute := startClock(day, pick.opts)
+		markup := keyboard.NewTimePickerKeyboard(dataPrefix, hour, minute, pick.opts.MinuteStep)
+		text := tr.T("datepicker.choose_time", i18n.Args{"question": pick.question, "date": tr.Date(day)})
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackMessage(bot, callback, text, &markup)
+
+	case keyboard.CalendarOpTime, keyboard.CalendarOpDone:
+		hour, minute, ok := parseClock(value)
+		if !ok || pick.day.IsZero() {
+			return AnswerCallback(bot, callback, "")
+		}
+		if op == keyboard.CalendarOpDone {
+			at := time.Date(pick.day.Year(), pick.day.Month(), pick.day.Day(), hour, minute, 0, 0, pick.opts.Location)
+			// Календарь проверяет только день: сегодняшняя дата с прошедшим часом сюда тоже дойдёт
+			if !timeAllowed(at, pick.opts) {
+				return AnswerCallback(bot, callback, tr.T("datepicker.time_unavailable"))
+			}
+			return p.finish(bot, callback, id, at)
+		}
+
+		markup := keyboard.NewTimePickerKeyboard(dataPrefix, hour, minute, pick.opts.MinuteStep)
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackKeyboard(bot, callback, &markup)
+
+	default:
+		// Заголовки и пустые клетки
+		return AnswerCallback(bot, callback, "")
+	}
+}
+
+// finish забирает запрос и передаёт выбранное время обработчику
+func (p *DatePicker) finish(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, id string, value time.Time) error {
+	entry, exists, foreign := p.pending.take(id, callback.From.ID)
+	if !exists || foreign {
+		// Дату уже выбрали параллельным нажатием
+		return AnswerCallback(bot, callback, "")
+	}
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+
+	text, err := entry.value.done(bot, callback, value)
+	if err != nil {
+		text = p.loc.For(callback.From).T("datepicker.failed")
+	}
+
+	if editErr := EditCallbackMessage(bot, callback, text, nil); editErr != nil {
+		log.Printf("Ошибка редактирования сообщения с календарём: %v", editErr)
+	}
+	return err
+}
+
+// calendarOptions переводит настройки компонента в настройки клавиатуры
+func (p *DatePicker) calendarOptions(opts DatePickerOptions) keyboard.CalendarOptions {
+	return keyboard.CalendarOptions{
+		Min:   opts.Min,
+		Max:   opts.Max,
+		Names: calendarNames(p.loc.Bundle().Translator(opts.Locale)),
+	}
+}
+
+// calendarNames берёт названия месяцев и дней недели из каталога сообщений.
+// В каталоге они перечислены через запятую, дни недели — начиная с воскресенья
+func calendarNames(tr *i18n.Translator) keyboard.CalendarNames {
+	var names keyboard.CalendarNames
+	copy(names.Months[:], splitNames(tr.T("calendar.months")))
+	copy(names.Weekdays[:], splitNames(tr.T("calendar.weekdays")))
+	if first, err := strconv.Atoi(tr.T("calendar.first_weekday")); err == nil && first >= 0 && first < 7 {
+		names.FirstDay = time.Weekday(first)
+	}
+	return names
+}
+
+// splitNames делит список названий, перечисленных через запятую
+func splitNames(list string) []string {
+	names := strings.Split(list, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
+
+// timeAllowed проверяет выбранный момент (дату вместе со временем) по Min и Max
+func timeAllowed(at time.Time, opts DatePickerOptions) bool {
+	if !opts.Min.IsZero() && at.Before(opts.Min) {
+		return false
+	}
+	if !opts.Max.IsZero() && at.After(opts.Max) {
+		return false
+	}
+	return true
+}
+
+// startClock выбирает время, с которого открывается выбор времени: 12:00,
+// а если в этот день оно недоступно — ближайшее доступное с шагом MinuteStep
+func startClock(day time.Time, opts DatePickerOptions) (hour, minute int) {
+	step := time.Duration(opts.MinuteStep) * time.Minute
+	at := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location())
+
+	if !opts.Min.IsZero() && at.Before(opts.Min) {
+		at = opts.Min.In(day.Location())
+		if rounded := at.Truncate(step); rounded.Before(at) {
+			at = rounded.Add(step)
+		}
+	}
+	if !opts.Max.IsZero() && at.After(opts.Max) {
+		at = opts.Max.In(day.Location()).Truncate(step)
+	}
+
+	// Границы в другом дне (например, Min в 23:58) — оставляем время по умолчанию
+	if at.YearDay() != day.YearDay() || at.Year() != day.Year() {
+		return 12, 0
+	}
+	return at.Hour(), at.Minute()
+}
+
+// parseClock разбирает время в формате "ЧЧММ"
+func parseClock(value string) (hour, minute int, ok bool) {
+	if len(value) != 4 {
+		return 0, 0, false
+	}
+
+	hour, errHour := strconv.Atoi(value[:2])
+	minute, errMinute := strconv.Atoi(value[2:])
+	if errHour != nil || errMinute != nil || hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
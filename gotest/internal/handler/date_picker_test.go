@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"telegram-bot/internal/i18n"
+)
+
+func TestTimeAllowed(t *testing.T) {
+	opts := DatePickerOptions{
+		Min: time.Date(2026, 10, 18, 14, 7, 0, 0, time.UTC),
+		Max: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"день Min, но раньше его времени", time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC), false},
+		{"ровно Min", opts.Min, true},
+		{"между границами", time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), true},
+		{"ровно Max", opts.Max, true},
+		{"день Max, но позже его времени", time.Date(2026, 10, 20, 9, 15, 0, 0, time.UTC), false},
+		{"Min в другом поясе", time.Date(2026, 10, 18, 17, 0, 0, 0, time.FixedZone("MSK", 3*60*60)), false},
+	}
+
+	for _, tt := range tests {
+		if got := timeAllowed(tt.at, opts); got != tt.want {
+			t.Errorf("%s: timeAllowed(%v) = %v, want %v", tt.name, tt.at, got, tt.want)
+		}
+	}
+
+	if !timeAllowed(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), DatePickerOptions{}) {
+		t.Error("без ограничений любое время должно быть доступно")
+	}
+}
+
+func TestStartClock(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		opts         DatePickerOptions
+		hour, minute int
+	}{
+		{"без ограничений", DatePickerOptions{MinuteStep: 15}, 12, 0},
+		{"Min в другой день", DatePickerOptions{MinuteStep: 15, Min: day.AddDate(0, 0, -1)}, 12, 0},
+		{"Min утром", DatePickerOptions{MinuteStep: 15, Min: day.Add(9 * time.Hour)}, 12, 0},
+		{"Min после полудня округляется вверх", DatePickerOptions{MinuteStep: 15, Min: day.Add(14*time.Hour + 7*time.Minute)}, 14, 15},
+		{"Min ровно на шаге", DatePickerOptions{MinuteStep: 15, Min: day.Add(14*time.Hour + 30*time.Minute)}, 14, 30},
+		{"Max утром округляется вниз", DatePickerOptions{MinuteStep: 15, Max: day.Add(9*time.Hour + 7*time.Minute)}, 9, 0},
+		{"Min в конце дня уходит на следующий", DatePickerOptions{MinuteStep: 15, Min: day.Add(23*time.Hour + 58*time.Minute)}, 12, 0},
+	}
+
+	for _, tt := range tests {
+		hour, minute := startClock(day, tt.opts)
+		if hour != tt.hour || minute != tt.minute {
+			t.Errorf("%s: startClock() = %02d:%02d, want %02d:%02d", tt.name, hour, minute, tt.hour, tt.minute)
+		}
+	}
+}
+
+func TestCalendarNames(t *testing.T) {
+	bundle, err := i18n.LoadDir("../../locales", "ru")
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+
+	tests := []struct {
+		lang     string
+		january  string
+		sunday   string
+		firstDay time.Weekday
+	}{
+		{"ru", "Январь", "Вс", time.Monday},
+		{"en", "January", "Su", time.Sunday},
+	}
+
+	for _, tt := range tests {
+		names := calendarNames(bundle.Translator(tt.lang))
+		if names.Months[0] != tt.january || names.Weekdays[0] != tt.sunday || names.FirstDay != tt.firstDay {
+			t.Errorf("%s: calendarNames() = %q, %q, %v", tt.lang, names.Months[0], names.Weekdays[0], names.FirstDay)
+		}
+		for i, month := range names.Months {
+			if month == "" {
+				t.Errorf("%s: нет названия месяца %d", tt.lang, i+1)
+			}
+		}
+		for i, weekday := range names.Weekdays {
+			if weekday == "" {
+				t.Errorf("%s: нет названия дня недели %d", tt.lang, i)
+			}
+		}
+	}
+}
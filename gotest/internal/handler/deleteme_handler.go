@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/privacy"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// deleteMeTTL — сколько действуют кнопки подтверждения удаления
+const deleteMeTTL = 2 * time.Minute
+
+// deleteProfilePrefix — префикс кнопок "Да"/"Нет", которые раньше прикреплялись
+// к приветствию /start (delete_profile_yes и delete_profile_no)
+const deleteProfilePrefix = "delete_profile"
+
+// DeleteMeHandler обрабатывает команду /deleteme — удаление всех данных пользователя
+type DeleteMeHandler struct {
+	confirm *ConfirmManager
+	privacy *privacy.Service
+	loc     *i18n.Localizer
+}
+
+// NewDeleteMeHandler создаёт новый обработчик команды /deleteme
+func NewDeleteMeHandler(confirm *ConfirmManager, privacy *privacy.Service, loc *i18n.Localizer) *DeleteMeHandler {
+	return &DeleteMeHandler{
+		confirm: confirm,
+		privacy: privacy,
+		loc:     loc,
+	}
+}
+
+// Command возвращает команду
+func (h *DeleteMeHandler) Command() string {
+	return "deleteme"
+}
+
+// Handle спрашивает подтверждение и после "Да" удаляет данные пользователя
+func (h *DeleteMeHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+
+	return h.confirm.Ask(bot, msg, tr.T("deleteme.question"), deleteMeTTL, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) (string, error) {
+		return h.erase(callback)
+	})
+}
+
+// HandleDeleteProfile обрабатывает кнопки delete_profile_yes/no под старыми приветствиями /start.
+// Кнопки убираются, а "Да" задаёт тот же вопрос с ограниченным временем, что и /deleteme:
+// одно нажатие на старое сообщение данные не удаляет
+func (h *DeleteMeHandler) HandleDeleteProfile(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	if err := EditCallbackKeyboard(bot, callback, nil); err != nil {
+		return err
+	}
+	if callback.Data != deleteProfilePrefix+"_yes" || callback.Message == nil {
+		return nil
+	}
+
+	// Вопрос задаётся от имени нажавшего: только он сможет его подтвердить
+	msg := &tgbotapi.Message{From: callback.From, Chat: callback.Message.Chat}
+	return h.Handle(bot, msg)
+}
+
+// erase удаляет данные нажавшего кнопку и возвращает текст о результате
+func (h *DeleteMeHandler) erase(callback *tgbotapi.CallbackQuery) (string, error) {
+	// Переводчик берём до удаления: после него выбранный язык забудется
+	tr := h.loc.For(callback.From)
+
+	userID := callback.From.ID
+	if err := h.privacy.Erase(context.Background(), userID, userID); err != nil {
+		return "", err
+	}
+	return tr.T("deleteme.done"), nil
+}
@@ -3,18 +3,22 @@ package handler
 import (
 	"log"
 
+	"telegram-bot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // Dispatcher управляет обработчиками команд
 type Dispatcher struct {
 	handlers map[string]Handler // Карта: команда -> обработчик
+	loc      *i18n.Localizer
 }
 
 // NewDispatcher создаёт новый диспетчер
-func NewDispatcher() *Dispatcher {
+func NewDispatcher(loc *i18n.Localizer) *Dispatcher {
 	return &Dispatcher{
 		handlers: make(map[string]Handler),
+		loc:      loc,
 	}
 }
 
@@ -25,6 +29,12 @@ func (d *Dispatcher) Register(handler Handler) {
 	log.Printf("Зарегистрирован обработчик команды /%s", command)
 }
 
+// Handler возвращает обработчик зарегистрированной команды
+func (d *Dispatcher) Handler(command string) (Handler, bool) {
+	handler, exists := d.handlers[command]
+	return handler, exists
+}
+
 // HandleCommand обрабатывает команду, направляя её к соответствующему обработчику
 func (d *Dispatcher) HandleCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
 	command := msg.Command()
@@ -49,7 +59,7 @@ func (d *Dispatcher) HandleCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message)
 // handleUnknownCommand обрабатывает неизвестные команды
 func (d *Dispatcher) handleUnknownCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
 	chatID := msg.Chat.ID
-	text := "Неизвестная команда. Используйте /help для списка доступных команд."
+	text := d.loc.For(msg.From).T("command.unknown")
 
 	reply := tgbotapi.NewMessage(chatID, text)
 	_, err := bot.Send(reply)
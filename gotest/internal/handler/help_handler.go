@@ -1,15 +1,21 @@
 package handler
 
 import (
+	"telegram-bot/internal/i18n"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // HelpHandler обрабатывает команду /help
-type HelpHandler struct{}
+type HelpHandler struct {
+	loc *i18n.Localizer
+}
 
 // NewHelpHandler создаёт новый обработчик команды /help
-func NewHelpHandler() *HelpHandler {
-	return &HelpHandler{}
+func NewHelpHandler(loc *i18n.Localizer) *HelpHandler {
+	return &HelpHandler{
+		loc: loc,
+	}
 }
 
 // Command возвращает команду
@@ -20,13 +26,7 @@ func (h *HelpHandler) Command() string {
 // Handle обрабатывает команду /help
 func (h *HelpHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
 	chatID := msg.Chat.ID
-
-	text := "Это справочная информация.\n\n" +
-		"<b>Доступные команды:</b>\n\n" +
-		"/start - начать работу с ботом\n" +
-		"/help - показать эту справку\n" +
-		"/info - информация о вашем профиле\n\n" +
-		"Бот создан с помощью библиотеки go-telegram-bot-api."
+	text := h.loc.For(msg.From).T("help.text")
 
 	reply := tgbotapi.NewMessage(chatID, text)
 	reply.ParseMode = tgbotapi.ModeHTML
@@ -1,17 +1,21 @@
 package handler
 
 import (
-	"fmt"
+	"telegram-bot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // InfoHandler обрабатывает команду /info
-type InfoHandler struct{}
+type InfoHandler struct {
+	loc *i18n.Localizer
+}
 
 // NewInfoHandler создаёт новый обработчик команды /info
-func NewInfoHandler() *InfoHandler {
-	return &InfoHandler{}
+func NewInfoHandler(loc *i18n.Localizer) *InfoHandler {
+	return &InfoHandler{
+		loc: loc,
+	}
 }
 
 // Command возвращает команду
@@ -23,21 +27,22 @@ func (h *InfoHandler) Command() string {
 func (h *InfoHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
 	chatID := msg.Chat.ID
 	user := msg.From
+	tr := h.loc.For(user)
 
-	info := "<b>Информация о вас:</b>\n\n"
-	info += fmt.Sprintf("<b>ID:</b> <code>%d</code>\n", user.ID)
-	info += fmt.Sprintf("<b>Имя:</b> %s\n", user.FirstName)
+	info := tr.T("info.title")
+	info += tr.T("info.id", i18n.Args{"id": user.ID})
+	info += tr.T("info.first_name", i18n.Args{"name": user.FirstName})
 
 	if user.LastName != "" {
-		info += fmt.Sprintf("<b>Фамилия:</b> %s\n", user.LastName)
+		info += tr.T("info.last_name", i18n.Args{"name": user.LastName})
 	}
 
 	if user.UserName != "" {
-		info += fmt.Sprintf("<b>Username:</b> @%s\n", user.UserName)
+		info += tr.T("info.username", i18n.Args{"username": user.UserName})
 	}
 
-	info += fmt.Sprintf("<b>Язык:</b> %s\n", user.LanguageCode)
-	info += fmt.Sprintf("<b>Бот:</b> %v\n", user.IsBot)
+	info += tr.T("info.language", i18n.Args{"language": user.LanguageCode})
+	info += tr.T("info.is_bot", i18n.Args{"is_bot": user.IsBot})
 
 	reply := tgbotapi.NewMessage(chatID, info)
 	reply.ParseMode = tgbotapi.ModeHTML
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"telegram-bot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// KeyboardHandler обрабатывает команду /keyboard — показывает главное меню на клавиатуре
+type KeyboardHandler struct {
+	menu *ReplyMenu
+	loc  *i18n.Localizer
+}
+
+// NewKeyboardHandler создаёт новый обработчик команды /keyboard
+func NewKeyboardHandler(menu *ReplyMenu, loc *i18n.Localizer) *KeyboardHandler {
+	return &KeyboardHandler{
+		menu: menu,
+		loc:  loc,
+	}
+}
+
+// Command возвращает команду
+func (h *KeyboardHandler) Command() string {
+	return "keyboard"
+}
+
+// Handle обрабатывает команду /keyboard
+func (h *KeyboardHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("keyboard.opened"))
+	reply.ReplyMarkup = h.menu.Keyboard(tr)
+	_, err := bot.Send(reply)
+	return err
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Параметры команды /log
+const (
+	logDefaultPeriod = 24 * time.Hour // Период, если он не указан
+	logMaxEntries    = 20             // Сколько последних записей показывать
+)
+
+// LogHandler обрабатывает команду /log <ID пользователя> [часов] —
+// показывает администратору, что пользователь присылал боту
+type LogHandler struct {
+	interactions repository.InteractionRepository
+	settings     *settings.Service
+	admins       middleware.AdminChecker
+	loc          *i18n.Localizer
+}
+
+// NewLogHandler создаёт новый обработчик команды /log
+func NewLogHandler(interactions repository.InteractionRepository, settings *settings.Service, admins middleware.AdminChecker, loc *i18n.Localizer) *LogHandler {
+	return &LogHandler{
+		interactions: interactions,
+		settings:     settings,
+		admins:       admins,
+		loc:          loc,
+	}
+}
+
+// Command возвращает команду
+func (h *LogHandler) Command() string {
+	return "log"
+}
+
+// Handle показывает последние записи журнала взаимодействий пользователя
+func (h *LogHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	userID, period, ok := parseLogArgs(msg.CommandArguments())
+	if !ok {
+		_, err := bot.Send(tgbotapi.NewMessage(msg.Chat.ID, tr.T("log.usage")))
+		return err
+	}
+
+	ctx := context.Background()
+	filter := repository.InteractionFilter{
+		UserID: userID,
+		Since:  time.Now().Add(-period),
+	}
+
+	total, err := h.interactions.Count(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("ошибка подсчёта записей журнала: %w", err)
+	}
+	entries, err := h.interactions.List(ctx, filter, 0, logMaxEntries)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения журнала: %w", err)
+	}
+
+	var text strings.Builder
+	text.WriteString(tr.T("log.title", i18n.Args{"user": userID, "period": tr.Duration(period)}))
+	text.WriteString("\n\n")
+
+	if len(entries) == 0 {
+		text.WriteString(tr.T("log.empty"))
+	}
+
+	// Время показываем в часовом поясе администратора
+	location := h.settings.Location(msg.From.ID)
+	for _, entry := range entries {
+		action := entry.Handler
+		if action == "" {
+			action = entry.UpdateType
+		}
+
+		fmt.Fprintf(&text, "<code>%s</code> %s %s", tr.DateTime(entry.CreatedAt.In(location)), outcomeIcon(entry.Outcome), html.EscapeString(action))
+		if entry.Text != "" {
+			fmt.Fprintf(&text, " · %s", html.EscapeString(entry.Text))
+		}
+		if entry.FileID != "" {
+			text.WriteString(" · 📎")
+		}
+		fmt.Fprintf(&text, " · %d ms\n", entry.Latency.Milliseconds())
+	}
+
+	if total > len(entries) {
+		text.WriteString("\n" + tr.T("log.shown", i18n.Args{"shown": len(entries), "total": total}))
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text.String())
+	reply.ParseMode = tgbotapi.ModeHTML
+	_, err = bot.Send(reply)
+	return err
+}
+
+// parseLogArgs разбирает аргументы "/log <ID> [часов]"
+func parseLogArgs(args string) (userID int64, period time.Duration, ok bool) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, 0, false
+	}
+
+	userID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	period = logDefaultPeriod
+	if len(fields) == 2 {
+		hours, err := strconv.Atoi(fields[1])
+		if err != nil || hours <= 0 {
+			return 0, 0, false
+		}
+		period = time.Duration(hours) * time.Hour
+	}
+	return userID, period, true
+}
+
+// outcomeIcon — значок результата обработки
+func outcomeIcon(outcome string) string {
+	switch outcome {
+	case domain.OutcomeOK:
+		return "✅"
+	case domain.OutcomeError:
+		return "❌"
+	default:
+		return "➖"
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import "telegram-bot/internal/i18n"
+
+// NewMainMenu создаёт главное меню бота на обычной клавиатуре.
+// Рядом с каждой кнопкой указан обработчик, который она запускает.
+// Подписи берутся из каталогов, поэтому нажатие распознаётся независимо от языка
+func NewMainMenu(bundle *i18n.Bundle, profile, settings, help, about, cancel Handler) *ReplyMenu {
+	return NewReplyMenu(bundle,
+		// Первый ряд
+		[]ReplyButton{
+			{Key: "button.profile", Handler: profile},
+			{Key: "button.settings", Handler: settings},
+		},
+		// Второй ряд
+		[]ReplyButton{
+			{Key: "button.help", Handler: help},
+			{Key: "button.about", Handler: about},
+		},
+		// Третий ряд (одна кнопка на весь ряд)
+		[]ReplyButton{
+			{Key: "button.cancel", Handler: cancel},
+		},
+	)
+}
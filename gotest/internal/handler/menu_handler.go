@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+	"telegram-bot/internal/menu"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// menuPrefix — префикс callback-данных кнопок меню
+const menuPrefix = "menu"
+
+// menuStackTTL — сколько хранить стек экранов сообщения после последнего перехода.
+// Для более старых сообщений кнопка "Назад" ведёт в корневое меню
+const menuStackTTL = 24 * time.Hour
+
+// Операции в callback-данных меню: "menu:o:<id>", "menu:b", "menu:a:<действие>"
+const (
+	menuOpOpen   = "o" // Открыть вложенное меню
+	menuOpBack   = "b" // Вернуться на предыдущий экран
+	menuOpAction = "a" // Запустить действие
+)
+
+// MenuAction — действие, которое запускает пункт меню
+type MenuAction func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error
+
+// RolesFunc возвращает роли пользователя (menu.RoleUser, menu.RoleAdmin и т.д.)
+type RolesFunc func(userID int64) []string
+
+// MenuHandler показывает иерархическое инлайн-меню, описанное в menu.Tree.
+// Переходы редактируют одно и то же сообщение, для кнопки "Назад" хранится стек экранов.
+// Заголовки, тексты и подписи пунктов — ключи каталогов сообщений (или готовый текст)
+type MenuHandler struct {
+	tree       *menu.Tree
+	dispatcher *Dispatcher           // Откуда брать обработчики команд для действий
+	roles      RolesFunc             // Роли пользователя для скрытия пунктов
+	actions    map[string]MenuAction // Действия, зарегистрированные помимо команд
+	loc        *i18n.Localizer
+
+	mu     sync.Mutex
+	stacks map[string]menuStack // Карта: "чат:сообщение" -> стек открытых экранов
+}
+
+// menuStack — стек ID открытых экранов одного сообщения с меню
+type menuStack struct {
+	ids       []string
+	updatedAt time.Time // Последний переход; по нему удаляются старые стеки
+}
+
+// NewMenuHandler создаёт новый обработчик меню.
+// Действия пунктов ищутся среди RegisterAction, а затем среди команд диспетчера
+func NewMenuHandler(tree *menu.Tree, dispatcher *Dispatcher, roles RolesFunc, loc *i18n.Localizer) *MenuHandler {
+	return &MenuHandler{
+		tree:       tree,
+		dispatcher: dispatcher,
+		roles:      roles,
+		actions:    make(map[string]MenuAction),
+		stacks:     make(map[string]menuStack),
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *MenuHandler) Command() string {
+	return "menu"
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *MenuHandler) Prefix() string {
+	return menuPrefix
+}
+
+// RegisterAction регистрирует действие, которое не является командой
+func (h *MenuHandler) RegisterAction(name string, action MenuAction) {
+	h.actions[name] = action
+}
+
+// Validate проверяет, что для каждого действия в меню есть обработчик
+func (h *MenuHandler) Validate() error {
+	for _, name := range h.tree.Actions() {
+		if _, exists := h.actions[name]; exists {
+			continue
+		}
+		if _, exists := h.dispatcher.Handler(name); exists {
+			continue
+		}
+		return fmt.Errorf("в меню используется неизвестное действие %q", name)
+	}
+	return nil
+}
+
+// Handle обрабатывает команду /menu — отправляет корневое меню
+func (h *MenuHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	root := h.tree.Root
+	text, markup, err := h.render(h.loc.For(msg.From), root, h.roles(msg.From.ID), false)
+	if err != nil {
+		return err
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+
+	sent, err := bot.Send(reply)
+	if err != nil {
+		return err
+	}
+
+	h.setStack(menuStackKey(sent.Chat.ID, sent.MessageID), []string{root.ID})
+	return nil
+}
+
+// HandleCallback обрабатывает нажатие на кнопку меню
+func (h *MenuHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	if callback.Message == nil {
+		return AnswerCallback(bot, callback, "")
+	}
+
+	payload := strings.TrimPrefix(callback.Data, menuPrefix+":")
+	op, arg, _ := strings.Cut(payload, ":")
+	tr := h.loc.For(callback.From)
+	roles := h.roles(callback.From.ID)
+
+	if op == menuOpAction {
+		return h.runAction(bot, callback, tr, roles, arg)
+	}
+
+	key := menuStackKey(callback.Message.Chat.ID, callback.Message.MessageID)
+	stack := h.stack(key)
+	if len(stack) == 0 {
+		// Стек потерян (например, после перезапуска бота) — начинаем с корня
+		stack = []string{h.tree.Root.ID}
+	}
+
+	switch op {
+	case menuOpOpen:
+		// Скрытый раздел нельзя открыть и подделанными callback-данными
+		if _, visible := h.tree.MenuFor(arg, roles); !visible {
+			return AnswerCallback(bot, callback, tr.T("menu.section_unavailable"))
+		}
+		if i := slices.Index(stack, arg); i >= 0 {
+			// Раздел уже открыт (повторное нажатие) — не кладём его в стек второй раз
+			stack = stack[:i+1]
+		} else {
+			stack = append(stack, arg)
+		}
+	case menuOpBack:
+		if len(stack) > 1 {
+			stack = stack[:len(stack)-1]
+		}
+	default:
+		return AnswerCallback(bot, callback, "")
+	}
+
+	current, visible := h.tree.MenuFor(stack[len(stack)-1], roles)
+	if !visible {
+		// Раздел исчез из меню или у пользователя отозваны права — возвращаемся в корень
+		stack = []string{h.tree.Root.ID}
+		current = h.tree.Root
+	}
+	text, markup, err := h.render(tr, current, roles, len(stack) > 1)
+	if err != nil {
+		_ = AnswerCallback(bot, callback, "")
+		return err
+	}
+
+	h.setStack(key, stack)
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	return EditCallbackMessageHTML(bot, callback, text, markup)
+}
+
+// runAction запускает действие пункта меню
+func (h *MenuHandler) runAction(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, tr *i18n.Translator, roles []string, name string) error {
+	// Скрытый пункт (или пункт скрытого раздела) нельзя запустить и подделанными callback-данными
+	if !h.tree.ActionVisibleTo(name, roles) {
+		return AnswerCallbackAlert(bot, callback, tr.T("menu.item_forbidden"))
+	}
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+
+	if action, exists := h.actions[name]; exists {
+		return action(bot, callback)
+	}
+
+	if handler, exists := h.dispatcher.Handler(name); exists {
+		return handler.Handle(bot, commandFromCallback(callback, name))
+	}
+
+	log.Printf("Для пункта меню %q не найден обработчик", name)
+	return nil
+}
+
+// render формирует текст и клавиатуру экрана с учётом ролей пользователя
+func (h *MenuHandler) render(tr *i18n.Translator, current *menu.Menu, roles []string, canGoBack bool) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	text := fmt.Sprintf("<b>%s</b>", tr.T(current.Title))
+	if current.Text != "" {
+		text += "\n\n" + tr.T(current.Text)
+	}
+
+	builder := keyboard.NewInlineBuilder().Columns(max(current.Columns, 1))
+	buttons := 0
+	for _, item := range current.Items {
+		if !item.VisibleTo(roles) {
+			continue
+		}
+
+		switch {
+		case item.Menu != nil:
+			builder.Callback(tr.T(item.Title), menuPrefix+":"+menuOpOpen+":"+item.Menu.ID)
+		case item.Action != "":
+			builder.Callback(tr.T(item.Title), menuPrefix+":"+menuOpAction+":"+item.Action)
+		default:
+			builder.URL(tr.T(item.Title), item.URL)
+		}
+		buttons++
+	}
+
+	if canGoBack {
+		builder.Row().Callback(tr.T("button.back"), menuPrefix+":"+menuOpBack)
+		buttons++
+	}
+
+	if buttons == 0 {
+		return text, nil, nil
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		return "", nil, fmt.Errorf("ошибка построения меню %q: %w", current.ID, err)
+	}
+
+	markup, err := built.Markup()
+	if err != nil {
+		return "", nil, fmt.Errorf("ошибка построения меню %q: %w", current.ID, err)
+	}
+	return text, &markup, nil
+}
+
+// stack возвращает копию стека экранов для сообщения
+func (h *MenuHandler) stack(key string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stack, exists := h.stacks[key]
+	if !exists || time.Since(stack.updatedAt) > menuStackTTL {
+		return nil
+	}
+	return slices.Clone(stack.ids)
+}
+
+// setStack сохраняет стек экранов для сообщения и удаляет устаревшие стеки
+func (h *MenuHandler) setStack(key string, ids []string) {
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for other, stack := range h.stacks {
+		if now.Sub(stack.updatedAt) > menuStackTTL {
+			delete(h.stacks, other)
+		}
+	}
+	h.stacks[key] = menuStack{ids: ids, updatedAt: now}
+}
+
+// menuStackKey формирует ключ стека по чату и сообщению
+func menuStackKey(chatID int64, messageID int) string {
+	return fmt.Sprintf("%d:%d", chatID, messageID)
+}
+
+// commandFromCallback превращает нажатие кнопки в сообщение с командой,
+// чтобы запустить обычный обработчик команды от имени нажавшего пользователя
+func commandFromCallback(callback *tgbotapi.CallbackQuery, command string) *tgbotapi.Message {
+	text := "/" + command
+	return &tgbotapi.Message{
+		MessageID: callback.Message.MessageID,
+		From:      callback.From,
+		Chat:      callback.Message.Chat,
+		Date:      callback.Message.Date,
+		Text:      text,
+		Entities: []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: len(text)},
+		},
+	}
+}
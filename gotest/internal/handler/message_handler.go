@@ -1,28 +1,82 @@
 package handler
 
 import (
-	"fmt"
+	"log"
 	"strings"
 
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/session"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// Flow — многошаговый сценарий. Пока сценарий активен в сессии пользователя,
+// его сообщения передаются сценарию, а не обычной обработке
+type Flow interface {
+	Name() string // Имя сценария, которое хранится в session.Session.Flow
+	HandleStep(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, sess session.Session) error
+}
+
 // MessageHandler обрабатывает обычные текстовые сообщения
-type MessageHandler struct{}
+type MessageHandler struct {
+	menu     *ReplyMenu      // Кнопки обычной клавиатуры
+	sessions *session.Store  // Активные сценарии пользователей
+	flows    map[string]Flow // Карта: имя сценария -> сценарий
+	loc      *i18n.Localizer
+}
 
 // NewMessageHandler создаёт новый обработчик сообщений
-func NewMessageHandler() *MessageHandler {
-	return &MessageHandler{}
+func NewMessageHandler(menu *ReplyMenu, sessions *session.Store, loc *i18n.Localizer) *MessageHandler {
+	return &MessageHandler{
+		menu:     menu,
+		sessions: sessions,
+		flows:    make(map[string]Flow),
+		loc:      loc,
+	}
+}
+
+// RegisterFlow регистрирует многошаговый сценарий
+func (h *MessageHandler) RegisterFlow(flow Flow) {
+	name := flow.Name()
+	h.flows[name] = flow
+	log.Printf("Зарегистрирован сценарий %s", name)
 }
 
-// Handle обрабатывает текстовое сообщение
+// InFlow сообщает, что у отправителя сообщения идёт сценарий.
+// Такому сценарию передаются и сообщения без текста: фото, документы, пересланные посты
+func (h *MessageHandler) InFlow(msg *tgbotapi.Message) bool {
+	return msg.From != nil && h.sessions.Get(msg.From.ID).Active()
+}
+
+// Handle обрабатывает сообщение: текстовое или ответ на шаг сценария
 func (h *MessageHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	// Нажатие на кнопку обычной клавиатуры — запускаем её обработчик.
+	// Кнопки проверяются первыми, чтобы "❌ Отмена" работала внутри сценария
+	if handler, exists := h.menu.Match(msg.Text); exists {
+		return handler.Handle(bot, msg)
+	}
+
+	// Идёт сценарий — сообщение является ответом на его шаг
+	if sess := h.sessions.Get(msg.From.ID); sess.Active() {
+		if flow, exists := h.flows[sess.Flow]; exists {
+			return flow.HandleStep(bot, msg, sess)
+		}
+		// Сценарий больше не зарегистрирован — забываем его
+		h.sessions.Reset(msg.From.ID)
+	}
+
+	// Сообщения без текста нужны только сценариям
+	if msg.Text == "" {
+		return nil
+	}
+
 	chatID := msg.Chat.ID
 	text := msg.Text
-	replyText := fmt.Sprintf("Вы написали: %s", text)
+	tr := h.loc.For(msg.From)
+	replyText := tr.T("message.echo", i18n.Args{"text": text})
 
 	if strings.Contains(msg.Text, "подпис") {
-		reply := tgbotapi.NewMessage(chatID, "О, опять про подписку? Денежки на орехи скопил? 😏\nНапиши мне по этому поводу в телеграм: @olegnastyle	")
+		reply := tgbotapi.NewMessage(chatID, tr.T("message.subscription"))
 		_, err := bot.Send(reply)
 		return err
 	} else {
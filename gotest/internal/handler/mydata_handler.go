@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/privacy"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// MyDataHandler обрабатывает команду /mydata — выгрузку всех данных пользователя.
+// "/mydata" присылает JSON, "/mydata zip" — архив с тем же JSON и CSV-файлами.
+// Выгрузка отправляется только в личный чат и не чаще одного раза за cooldown
+type MyDataHandler struct {
+	privacy  *privacy.Service
+	cooldown time.Duration
+	loc      *i18n.Localizer
+}
+
+// NewMyDataHandler создаёт новый обработчик команды /mydata
+func NewMyDataHandler(privacy *privacy.Service, cooldown time.Duration, loc *i18n.Localizer) *MyDataHandler {
+	return &MyDataHandler{
+		privacy:  privacy,
+		cooldown: cooldown,
+		loc:      loc,
+	}
+}
+
+// Command возвращает команду
+func (h *MyDataHandler) Command() string {
+	return "mydata"
+}
+
+// Handle собирает данные пользователя и отправляет их файлом
+func (h *MyDataHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	userID := msg.From.ID
+
+	// В группе выгрузку увидели бы все участники
+	if !msg.Chat.IsPrivate() {
+		_, err := bot.Send(tgbotapi.NewMessage(msg.Chat.ID, tr.T("mydata.private_only")))
+		return err
+	}
+
+	ctx := context.Background()
+	wait, err := h.wait(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if wait > 0 {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("mydata.cooldown", i18n.Args{"wait": tr.Duration(wait)}))
+		_, err := bot.Send(reply)
+		return err
+	}
+
+	export, err := h.privacy.Export(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		data   []byte
+		format string
+	)
+	if strings.EqualFold(strings.TrimSpace(msg.CommandArguments()), "zip") {
+		format = "zip"
+		data, err = export.Zip()
+	} else {
+		format = "json"
+		data, err = export.JSON()
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка формирования выгрузки: %w", err)
+	}
+	name := fmt.Sprintf("mydata-%d.%s", userID, format)
+	document := tgbotapi.NewDocument(userID, tgbotapi.FileBytes{Name: name, Bytes: data})
+	document.Caption = tr.T("mydata.caption")
+	if _, err := bot.Send(document); err != nil {
+		return err
+	}
+
+	return h.privacy.RecordExport(ctx, userID, format)
+}
+
+// wait возвращает, сколько осталось ждать до следующей выгрузки (0 — можно сейчас)
+func (h *MyDataHandler) wait(ctx context.Context, userID int64, now time.Time) (time.Duration, error) {
+	last, err := h.privacy.LastExport(ctx, userID)
+	if err != nil || last.IsZero() {
+		return 0, err
+	}
+
+	left := h.cooldown - now.Sub(last)
+	if left <= 0 {
+		return 0, nil
+	}
+	return left, nil
+}
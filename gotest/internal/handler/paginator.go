@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// PageItem — элемент постраничного списка
+type PageItem struct {
+	Text   string // Строка элемента в тексте сообщения (HTML)
+	Button string // Подпись кнопки элемента (пусто — используется Text)
+	Data   string // Callback-данные кнопки элемента (пусто — без кнопки)
+}
+
+// PageSource — источник данных для постраничного списка.
+// Реализуется поверх любого хранилища: пользователи, логи, чаты
+type PageSource interface {
+	Count() (int, error)                         // Общее количество элементов
+	Items(offset, limit int) ([]PageItem, error) // Элементы одной страницы
+}
+
+// defaultPageSize — размер страницы, если он не задан
+const defaultPageSize = 10
+
+// Paginator показывает список по страницам и сам обрабатывает кнопки навигации
+type Paginator struct {
+	prefix   string     // Префикс callback-данных
+	title    string     // Ключ заголовка над списком в каталогах сообщений
+	pageSize int        // Количество элементов на странице
+	source   PageSource // Откуда брать элементы
+	loc      *i18n.Localizer
+}
+
+// NewPaginator создаёт новый постраничный список
+func NewPaginator(prefix, title string, pageSize int, source PageSource, loc *i18n.Localizer) *Paginator {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	return &Paginator{
+		prefix:   prefix,
+		title:    title,
+		pageSize: pageSize,
+		source:   source,
+		loc:      loc,
+	}
+}
+
+// Prefix возвращает префикс callback-данных
+func (p *Paginator) Prefix() string {
+	return p.prefix
+}
+
+// Send отправляет первую страницу списка в ответ на сообщение (на языке его автора)
+func (p *Paginator) Send(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	text, markup, err := p.render(p.loc.For(msg.From), 1)
+	if err != nil {
+		return err
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+	_, err = bot.Send(reply)
+	return err
+}
+
+// HandleCallback обрабатывает переход на другую страницу
+func (p *Paginator) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	page, err := strconv.Atoi(strings.TrimPrefix(callback.Data, p.prefix+":"))
+	if err != nil {
+		// Кнопка элемента с чужими данными попала сюда по ошибке — игнорируем
+		return AnswerCallback(bot, callback, "")
+	}
+
+	tr := p.loc.For(callback.From)
+	text, markup, err := p.render(tr, page)
+	if err != nil {
+		_ = AnswerCallback(bot, callback, tr.T("pagination.failed"))
+		return err
+	}
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	return EditCallbackMessageHTML(bot, callback, text, markup)
+}
+
+// render формирует текст и клавиатуру страницы
+func (p *Paginator) render(tr *i18n.Translator, page int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	total, err := p.source.Count()
+	if err != nil {
+		return "", nil, fmt.Errorf("ошибка подсчёта элементов списка: %w", err)
+	}
+
+	title := tr.T(p.title)
+	if total == 0 {
+		return fmt.Sprintf("<b>%s</b>\n\n%s", title, tr.T("pagination.empty")), nil, nil
+	}
+
+	// Страница могла исчезнуть, пока список был открыт
+	pages := (total + p.pageSize - 1) / p.pageSize
+	page = min(max(page, 1), pages)
+
+	items, err := p.source.Items((page-1)*p.pageSize, p.pageSize)
+	if err != nil {
+		return "", nil, fmt.Errorf("ошибка загрузки страницы %d: %w", page, err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "<b>%s</b>\n\n", title)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, item := range items {
+		fmt.Fprintf(&text, "%d. %s\n", (page-1)*p.pageSize+i+1, item.Text)
+
+		if item.Data != "" {
+			label := item.Button
+			if label == "" {
+				label = item.Text
+			}
+			button := tgbotapi.NewInlineKeyboardButtonData(label, item.Data)
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+		}
+	}
+	text.WriteString("\n" + tr.T("pagination.page", i18n.Args{"page": page, "pages": pages}))
+
+	if navigation := keyboard.NewPaginationRow(p.prefix, page, pages); navigation != nil {
+		rows = append(rows, navigation)
+	}
+	if len(rows) == 0 {
+		return text.String(), nil, nil
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text.String(), &markup, nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"telegram-bot/internal/i18n"
+)
+
+// itemsSource — источник из n элементов "item 1".."item n"
+type itemsSource struct {
+	n int
+}
+
+func (s itemsSource) Count() (int, error) {
+	return s.n, nil
+}
+
+func (s itemsSource) Items(offset, limit int) ([]PageItem, error) {
+	var items []PageItem
+	for i := offset; i < min(offset+limit, s.n); i++ {
+		items = append(items, PageItem{Text: fmt.Sprintf("item %d", i+1)})
+	}
+	return items, nil
+}
+
+func TestPaginatorRenderClampsPage(t *testing.T) {
+	bundle, err := i18n.LoadDir("../../locales", "ru")
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	tr := bundle.Translator("en")
+
+	tests := []struct {
+		name      string
+		total     int
+		pageSize  int
+		page      int
+		wantPage  string // Подпись "Page X of Y"
+		wantFirst string // Первый элемент страницы
+		wantNav   bool   // Есть ли ряд навигации
+	}{
+		{"первая страница", 25, 10, 1, "Page 1 of 3", "1. item 1", true},
+		{"последняя неполная страница", 25, 10, 3, "Page 3 of 3", "21. item 21", true},
+		{"страница после последней", 25, 10, 7, "Page 3 of 3", "21. item 21", true},
+		{"нулевая страница", 25, 10, 0, "Page 1 of 3", "1. item 1", true},
+		{"отрицательная страница", 25, 10, -4, "Page 1 of 3", "1. item 1", true},
+		{"ровно одна страница", 10, 10, 2, "Page 1 of 1", "1. item 1", false},
+		{"размер страницы по умолчанию", 11, 0, 2, "Page 2 of 2", "11. item 11", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPaginator("p", "chats.title", tt.pageSize, itemsSource{n: tt.total}, nil)
+			text, markup, err := p.render(tr, tt.page)
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+
+			if !strings.Contains(text, tt.wantPage) {
+				t.Errorf("text %q does not contain %q", text, tt.wantPage)
+			}
+			if !strings.Contains(text, tt.wantFirst+"\n") {
+				t.Errorf("text %q does not contain %q", text, tt.wantFirst)
+			}
+			if hasNav := markup != nil; hasNav != tt.wantNav {
+				t.Errorf("navigation = %v, want %v", hasNav, tt.wantNav)
+			}
+		})
+	}
+}
+
+func TestPaginatorRenderEmpty(t *testing.T) {
+	bundle, err := i18n.LoadDir("../../locales", "ru")
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	tr := bundle.Translator("en")
+
+	p := NewPaginator("p", "chats.title", 10, itemsSource{}, nil)
+	text, markup, err := p.render(tr, 3)
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if markup != nil {
+		t.Errorf("markup = %v, want nil", markup)
+	}
+	if want := tr.T("pagination.empty"); !strings.Contains(text, want) {
+		t.Errorf("text %q does not contain %q", text, want)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pendingIDLength — длина ID запроса в callback-данных (UUID в текстовом виде)
+const pendingIDLength = 36
+
+// pendingEntry — ожидающий ответа пользователя запрос (подтверждение, выбор даты)
+type pendingEntry[T any] struct {
+	userID    int64     // Кто может отвечать на запрос
+	expiresAt time.Time // Когда запрос перестаёт действовать
+	value     T         // Данные запроса
+}
+
+// expired сообщает, что время ответа на запрос истекло
+func (e pendingEntry[T]) expired(now time.Time) bool {
+	return now.After(e.expiresAt)
+}
+
+// pendingStore хранит запросы, связанные с кнопками, по случайному ID,
+// который передаётся в callback-данных
+type pendingStore[T any] struct {
+	mu      sync.Mutex
+	entries map[string]pendingEntry[T] // Карта: ID запроса -> запрос
+}
+
+// newPendingStore создаёт пустое хранилище запросов
+func newPendingStore[T any]() *pendingStore[T] {
+	return &pendingStore[T]{
+		entries: make(map[string]pendingEntry[T]),
+	}
+}
+
+// add сохраняет запрос и возвращает его ID
+func (s *pendingStore[T]) add(userID int64, ttl time.Duration, value T) string {
+	id := uuid.NewString()
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired(now)
+	s.entries[id] = pendingEntry[T]{
+		userID:    userID,
+		expiresAt: now.Add(ttl),
+		value:     value,
+	}
+	return id
+}
+
+// get возвращает запрос, не удаляя его
+func (s *pendingStore[T]) get(id string) (pendingEntry[T], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists := s.entries[id]
+	return entry, exists
+}
+
+// update заменяет данные запроса, если он ещё существует
+func (s *pendingStore[T]) update(id string, value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, exists := s.entries[id]; exists {
+		entry.value = value
+		s.entries[id] = entry
+	}
+}
+
+// take удаляет запрос и возвращает его, если на него может ответить userID.
+// Запрос забирается под блокировкой, поэтому повторное нажатие его уже не найдёт.
+// foreign == true означает, что запрос существует, но принадлежит другому пользователю
+func (s *pendingStore[T]) take(id string, userID int64) (entry pendingEntry[T], exists, foreign bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, exists = s.entries[id]
+	if exists && entry.userID != userID {
+		return entry, true, true
+	}
+
+	delete(s.entries, id)
+	return entry, exists, false
+}
+
+// remove удаляет запрос
+func (s *pendingStore[T]) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, id)
+}
+
+// removeExpired удаляет просроченные запросы (вызывается под блокировкой)
+func (s *pendingStore[T]) removeExpired(now time.Time) {
+	for id, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, id)
+		}
+	}
+}
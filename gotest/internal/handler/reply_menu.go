@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"strings"
+
+	"telegram-bot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ReplyButton — кнопка обычной клавиатуры вместе с обработчиком, который она запускает
+type ReplyButton struct {
+	Key     string  // Ключ подписи кнопки в каталогах сообщений
+	Handler Handler // Что выполнить при нажатии
+}
+
+// ReplyMenu — обычная клавиатура, кнопки которой связаны с обработчиками.
+// Нажатие на кнопку приходит обычным текстом, по нему и ищется обработчик
+type ReplyMenu struct {
+	rows   [][]ReplyButton
+	bundle *i18n.Bundle
+}
+
+// NewReplyMenu создаёт клавиатуру из рядов кнопок.
+// Нажатие распознаётся по подписи на любом из языков bundle
+func NewReplyMenu(bundle *i18n.Bundle, rows ...[]ReplyButton) *ReplyMenu {
+	return &ReplyMenu{
+		rows:   rows,
+		bundle: bundle,
+	}
+}
+
+// Keyboard возвращает разметку клавиатуры на языке пользователя
+func (m *ReplyMenu) Keyboard(tr *i18n.Translator) tgbotapi.ReplyKeyboardMarkup {
+	rows := make([][]tgbotapi.KeyboardButton, 0, len(m.rows))
+	for _, row := range m.rows {
+		buttons := make([]tgbotapi.KeyboardButton, 0, len(row))
+		for _, button := range row {
+			buttons = append(buttons, tgbotapi.NewKeyboardButton(tr.T(button.Key)))
+		}
+		rows = append(rows, tgbotapi.NewKeyboardButtonRow(buttons...))
+	}
+
+	keyboard := tgbotapi.NewReplyKeyboard(rows...)
+	keyboard.ResizeKeyboard = true
+	return keyboard
+}
+
+// Match ищет обработчик кнопки по тексту сообщения.
+// Подписи берутся из каталогов при каждом сравнении, поэтому
+// после перезагрузки переводов новые подписи распознаются сразу
+func (m *ReplyMenu) Match(text string) (Handler, bool) {
+	text = normalizeLabel(text)
+	for _, row := range m.rows {
+		for _, button := range row {
+			for _, label := range m.bundle.All(button.Key) {
+				if normalizeLabel(label) == text {
+					return button.Handler, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// normalizeLabel приводит подпись к виду для сравнения: клиенты Telegram
+// могут убрать селектор варианта эмодзи (U+FE0F) и пробелы по краям
+func normalizeLabel(label string) string {
+	label = strings.ReplaceAll(label, "\uFE0F", "")
+	return strings.ToLower(strings.TrimSpace(label))
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/moderation"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// restrictionReasonLimit — сколько символов причины сохранять
+const restrictionReasonLimit = 200
+
+// restrictionDurationPattern — срок ограничения: 30m, 12h, 7d, 2w
+var restrictionDurationPattern = regexp.MustCompile(`^(\d{1,4})([mhdw])$`)
+
+// restrictionUnits — длительность единицы срока ограничения
+var restrictionUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// restrictionResults — ответ администратору для каждого вида ограничения
+var restrictionResults = []struct {
+	kind string
+	key  string
+}{
+	{kind: domain.RestrictionBan, key: "restriction.done.ban"},
+	{kind: domain.RestrictionMute, key: "restriction.done.mute"},
+}
+
+// RestrictHandler обрабатывает команды /ban и /mute — ограничение пользователя:
+//
+//	/ban <ID> [срок] [причина]   — или ответом на сообщение пользователя: /ban [срок] [причина]
+//	/mute <ID> [срок] [причина]
+//
+// Срок — 30m, 12h, 7d, 2w; без срока ограничение бессрочное
+type RestrictHandler struct {
+	kind       string // Вид ограничения (domain.Restriction*), он же команда
+	moderation *moderation.Service
+	users      repository.UserRepository
+	settings   *settings.Service
+	admins     middleware.AdminChecker
+	loc        *i18n.Localizer
+}
+
+// NewRestrictHandler создаёт обработчик команды, накладывающей ограничение вида kind
+func NewRestrictHandler(kind string, moderation *moderation.Service, users repository.UserRepository, settings *settings.Service, admins middleware.AdminChecker, loc *i18n.Localizer) *RestrictHandler {
+	return &RestrictHandler{
+		kind:       kind,
+		moderation: moderation,
+		users:      users,
+		settings:   settings,
+		admins:     admins,
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *RestrictHandler) Command() string {
+	return h.kind
+}
+
+// Handle накладывает ограничение на пользователя
+func (h *RestrictHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	userID, args, ok := commandTarget(msg)
+	if !ok {
+		return sendHTML(bot, msg.Chat.ID, tr.T("restriction.usage", i18n.Args{"command": h.kind}), nil)
+	}
+	if h.admins.IsAdmin(userID) {
+		return sendHTML(bot, msg.Chat.ID, tr.T("restriction.admin"), nil)
+	}
+
+	restriction := domain.Restriction{
+		UserID:    userID,
+		Kind:      h.kind,
+		CreatedBy: msg.From.ID,
+	}
+	if len(args) > 0 {
+		if duration, ok := parseRestrictionDuration(args[0]); ok {
+			restriction.ExpiresAt = time.Now().Add(duration).UTC()
+			args = args[1:]
+		}
+	}
+	restriction.Reason = truncateRunes(strings.Join(args, " "), restrictionReasonLimit)
+
+	ctx := context.Background()
+	if err := h.moderation.Restrict(ctx, restriction); err != nil {
+		return err
+	}
+
+	until := tr.T("restriction.forever")
+	if !restriction.IsPermanent() {
+		until = tr.T("restriction.until", i18n.Args{"time": tr.DateTime(restriction.ExpiresAt.In(h.settings.Location(msg.From.ID)))})
+	}
+
+	var text string
+	for _, result := range restrictionResults {
+		if result.kind == h.kind {
+			text = tr.T(result.key, i18n.Args{"user": userLabel(ctx, h.users, userID), "until": until})
+		}
+	}
+	if restriction.Reason != "" {
+		text += "\n" + tr.T("restriction.reason", i18n.Args{"reason": html.EscapeString(restriction.Reason)})
+	}
+	return sendHTML(bot, msg.Chat.ID, text, nil)
+}
+
+// UnbanHandler обрабатывает команду /unban <ID> (или ответом на сообщение) —
+// снимает с пользователя блокировку или запрет писать
+type UnbanHandler struct {
+	moderation *moderation.Service
+	users      repository.UserRepository
+	admins     middleware.AdminChecker
+	loc        *i18n.Localizer
+}
+
+// NewUnbanHandler создаёт новый обработчик команды /unban
+func NewUnbanHandler(moderation *moderation.Service, users repository.UserRepository, admins middleware.AdminChecker, loc *i18n.Localizer) *UnbanHandler {
+	return &UnbanHandler{
+		moderation: moderation,
+		users:      users,
+		admins:     admins,
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *UnbanHandler) Command() string {
+	return "unban"
+}
+
+// Handle снимает ограничение с пользователя
+func (h *UnbanHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	userID, _, ok := commandTarget(msg)
+	if !ok {
+		return sendHTML(bot, msg.Chat.ID, tr.T("restriction.unban_usage"), nil)
+	}
+
+	ctx := context.Background()
+	lifted, err := h.moderation.Lift(ctx, userID, msg.From.ID)
+	if err != nil {
+		return err
+	}
+
+	args := i18n.Args{"user": userLabel(ctx, h.users, userID)}
+	if !lifted {
+		return sendHTML(bot, msg.Chat.ID, tr.T("restriction.not_restricted", args), nil)
+	}
+	return sendHTML(bot, msg.Chat.ID, tr.T("restriction.lifted", args), nil)
+}
+
+// commandTarget определяет, к кому относится команда: к автору сообщения,
+// на которое ответил администратор, или к пользователю с ID из первого аргумента.
+// Возвращает ID пользователя и оставшиеся аргументы
+func commandTarget(msg *tgbotapi.Message) (int64, []string, bool) {
+	args := strings.Fields(msg.CommandArguments())
+
+	if reply := msg.ReplyToMessage; reply != nil && reply.From != nil && !reply.From.IsBot {
+		return reply.From.ID, args, true
+	}
+	if len(args) == 0 {
+		return 0, nil, false
+	}
+
+	userID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, nil, false
+	}
+	return userID, args[1:], true
+}
+
+// parseRestrictionDuration разбирает срок ограничения вида 30m, 12h, 7d, 2w
+func parseRestrictionDuration(value string) (time.Duration, bool) {
+	match := restrictionDurationPattern.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return 0, false
+	}
+
+	count, err := strconv.Atoi(match[1])
+	if err != nil || count == 0 {
+		return 0, false
+	}
+	return time.Duration(count) * restrictionUnits[match[2]], true
+}
+
+// userLabel описывает пользователя для администратора: имя (если бот его знает) и ID
+func userLabel(ctx context.Context, users repository.UserRepository, userID int64) string {
+	user, err := users.GetByID(ctx, userID)
+	if err != nil || user.FirstName == "" {
+		return fmt.Sprintf("<code>%d</code>", userID)
+	}
+	return fmt.Sprintf("%s (<code>%d</code>)", html.EscapeString(user.FirstName), userID)
+}
+
+// truncateRunes обрезает строку до limit символов
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
@@ -0,0 +1,571 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/broadcast"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/session"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	schedulePrefix = "sched"          // Префикс callback-данных: "sched:<действие>[:<id>]"
+	scheduleFlow   = "schedule"       // Сценарий подготовки и изменения рассылки по расписанию
+	scheduleTTL    = 10 * time.Minute // Сколько действуют кнопки подтверждения отмены
+
+	stepScheduleMessage  = "message"  // Шаг: ждём сообщение для рассылки
+	stepScheduleAudience = "audience" // Шаг: ждём новую аудиторию
+
+	scheduleIDKey       = "id" // Ключ ID изменяемой рассылки в данных сессии (пусто — новая рассылка)
+	scheduleEveryoneArg = "*"  // Ответ "все подписчики" на вопрос об аудитории
+)
+
+// Действия кнопок списка и карточки рассылки по расписанию
+const (
+	scheduleActionList       = "list"
+	scheduleActionOpen       = "open"
+	scheduleActionTime       = "time"
+	scheduleActionRecurrence = "repeat"
+	scheduleActionAudience   = "audience"
+	scheduleActionMessage    = "message"
+	scheduleActionPreview    = "preview"
+	scheduleActionCancel     = "cancel"
+)
+
+// scheduleRecurrences — варианты повтора по порядку переключения кнопкой
+var scheduleRecurrences = []struct {
+	value string
+	key   string
+}{
+	{value: domain.RecurrenceNone, key: "schedule.recurrence.none"},
+	{value: domain.RecurrenceDaily, key: "schedule.recurrence.daily"},
+	{value: domain.RecurrenceWeekly, key: "schedule.recurrence.weekly"},
+}
+
+// scheduleStatuses — пометка в карточке рассылки, которая больше не будет отправлена
+var scheduleStatuses = []struct {
+	status string
+	key    string
+}{
+	{status: domain.ScheduleCancelled, key: "schedule.status.cancelled"},
+	{status: domain.ScheduleDone, key: "schedule.status.done"},
+}
+
+// ScheduleHandler обрабатывает команду /schedule — рассылки по расписанию:
+//
+//	/schedule                           — список запланированных рассылок
+//	/schedule new [сегмент | условия]   — запланировать рассылку
+//
+// Время, повтор, аудитория и сообщение запланированной рассылки меняются кнопками её карточки
+type ScheduleHandler struct {
+	scheduler  *broadcast.Scheduler
+	broadcasts *broadcast.Service
+	settings   *settings.Service
+	sessions   *session.Store
+	confirm    *ConfirmManager
+	datePicker *DatePicker
+	admins     middleware.AdminChecker
+	loc        *i18n.Localizer
+}
+
+// NewScheduleHandler создаёт новый обработчик команды /schedule
+func NewScheduleHandler(scheduler *broadcast.Scheduler, broadcasts *broadcast.Service, settings *settings.Service, sessions *session.Store, confirm *ConfirmManager, datePicker *DatePicker, admins middleware.AdminChecker, loc *i18n.Localizer) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduler:  scheduler,
+		broadcasts: broadcasts,
+		settings:   settings,
+		sessions:   sessions,
+		confirm:    confirm,
+		datePicker: datePicker,
+		admins:     admins,
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *ScheduleHandler) Command() string {
+	return "schedule"
+}
+
+// Name возвращает имя сценария
+func (h *ScheduleHandler) Name() string {
+	return scheduleFlow
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *ScheduleHandler) Prefix() string {
+	return schedulePrefix
+}
+
+// Handle показывает список рассылок по расписанию или начинает подготовку новой
+func (h *ScheduleHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	action, args, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	switch action {
+	case "", scheduleActionList:
+		text, markup, err := h.renderList(context.Background(), tr)
+		if err != nil {
+			return err
+		}
+		return sendHTML(bot, msg.Chat.ID, text, markup)
+	case "new":
+		return h.startNew(bot, msg, tr, args)
+	default:
+		return sendHTML(bot, msg.Chat.ID, tr.T("schedule.usage"), nil)
+	}
+}
+
+// startNew определяет аудиторию новой рассылки и просит прислать сообщение.
+// Аудитория может быть пустой: к моменту отправки в ней могут появиться получатели
+func (h *ScheduleHandler) startNew(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, spec string) error {
+	ctx := context.Background()
+	location := h.settings.Location(msg.From.ID)
+	audience, err := h.broadcasts.Audience(ctx, spec, location)
+	if text, ok := audienceErrorText(tr, err); ok {
+		return sendHTML(bot, msg.Chat.ID, text, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	recipients, err := h.broadcasts.Recipients(ctx, audience)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(audience)
+	if err != nil {
+		return err
+	}
+	sess := h.sessions.Start(msg.From.ID, scheduleFlow, stepScheduleMessage)
+	sess.Data[broadcastAudienceKey] = string(encoded)
+	h.sessions.Save(msg.From.ID, sess)
+
+	return sendHTML(bot, msg.Chat.ID, tr.T("schedule.compose", i18n.Args{
+		"audience":   describeAudience(tr, audience, location),
+		"recipients": tr.N("broadcast.recipients", int64(len(recipients))),
+	}), nil)
+}
+
+// HandleStep принимает сообщение для рассылки или новую аудиторию
+func (h *ScheduleHandler) HandleStep(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, sess session.Session) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		h.sessions.Reset(msg.From.ID)
+		return nil
+	}
+
+	// Без ID — новая рассылка, с ID — изменение запланированной
+	var id int64
+	if value := sess.Data[scheduleIDKey]; value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			h.sessions.Reset(msg.From.ID)
+			return fmt.Errorf("неверный ID рассылки по расписанию в сессии: %q", value)
+		}
+		id = parsed
+	}
+
+	switch sess.Step {
+	case stepScheduleMessage:
+		return h.handleMessage(bot, msg, tr, sess, id)
+	case stepScheduleAudience:
+		return h.handleAudience(bot, msg, tr, id)
+	default:
+		h.sessions.Reset(msg.From.ID)
+		return nil
+	}
+}
+
+// handleMessage показывает присланное сообщение и либо спрашивает время отправки
+// новой рассылки, либо заменяет сообщение запланированной
+func (h *ScheduleHandler) handleMessage(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, sess session.Session, id int64) error {
+	draft := domain.Broadcast{
+		AuthorID:        msg.From.ID,
+		SourceChatID:    msg.Chat.ID,
+		SourceMessageID: msg.MessageID,
+		Forward:         msg.ForwardDate != 0,
+	}
+	if err := previewBroadcast(bot, msg, tr, draft); err != nil {
+		return err
+	}
+
+	if id != 0 {
+		h.sessions.Reset(msg.From.ID)
+		schedule, err := h.scheduler.Edit(context.Background(), id, func(schedule *domain.ScheduledBroadcast) {
+			schedule.SourceChatID = draft.SourceChatID
+			schedule.SourceMessageID = draft.SourceMessageID
+			schedule.Forward = draft.Forward
+		})
+		return h.replyEdited(bot, msg, tr, schedule, err)
+	}
+
+	if err := json.Unmarshal([]byte(sess.Data[broadcastAudienceKey]), &draft.Audience); err != nil {
+		h.sessions.Reset(msg.From.ID)
+		return fmt.Errorf("ошибка чтения аудитории рассылки из сессии: %w", err)
+	}
+	h.sessions.Reset(msg.From.ID)
+
+	location := h.settings.Location(msg.From.ID)
+	schedule := domain.ScheduledBroadcast{
+		AuthorID:        draft.AuthorID,
+		SourceChatID:    draft.SourceChatID,
+		SourceMessageID: draft.SourceMessageID,
+		Forward:         draft.Forward,
+		Audience:        draft.Audience,
+		Recurrence:      domain.RecurrenceNone,
+		TimeZone:        location.String(),
+	}
+	return h.askNewTime(bot, msg, tr, schedule)
+}
+
+// askNewTime спрашивает время отправки новой рассылки и сохраняет её.
+// Если выбранное время уже прошло, спрашивает ещё раз
+func (h *ScheduleHandler) askNewTime(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, schedule domain.ScheduledBroadcast) error {
+	opts := DatePickerOptions{
+		Location: schedule.Location(),
+		Min:      time.Now(),
+		WithTime: true,
+	}
+	return h.datePicker.Ask(bot, msg, tr.T("schedule.ask_time"), opts, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, value time.Time) (string, error) {
+		tr := h.loc.For(callback.From)
+
+		created := schedule
+		created.NextRunAt = value.UTC()
+		err := h.scheduler.Schedule(context.Background(), &created)
+		if errors.Is(err, broadcast.ErrScheduleInPast) {
+			return tr.T("schedule.past"), h.askNewTime(bot, msg, tr, schedule)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// Повтор и остальное настраиваются в карточке рассылки
+		text, markup := h.renderCard(tr, &created)
+		if err := sendHTML(bot, msg.Chat.ID, text, markup); err != nil {
+			return "", err
+		}
+		return tr.T("schedule.created", i18n.Args{"id": created.ID}), nil
+	})
+}
+
+// handleAudience меняет аудиторию запланированной рассылки
+func (h *ScheduleHandler) handleAudience(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, id int64) error {
+	spec := strings.TrimSpace(msg.Text)
+	if spec == "" {
+		return sendHTML(bot, msg.Chat.ID, tr.T("schedule.ask_audience"), nil)
+	}
+	if spec == scheduleEveryoneArg {
+		spec = ""
+	}
+
+	ctx := context.Background()
+	audience, err := h.broadcasts.Audience(ctx, spec, h.settings.Location(msg.From.ID))
+	if text, ok := audienceErrorText(tr, err); ok {
+		// Администратор может исправить условия, сценарий продолжается
+		return sendHTML(bot, msg.Chat.ID, text, nil)
+	}
+	if err != nil {
+		return err
+	}
+	h.sessions.Reset(msg.From.ID)
+
+	schedule, err := h.scheduler.Edit(ctx, id, func(schedule *domain.ScheduledBroadcast) {
+		schedule.Audience = audience
+	})
+	return h.replyEdited(bot, msg, tr, schedule, err)
+}
+
+// replyEdited сообщает результат изменения рассылки и показывает её карточку
+func (h *ScheduleHandler) replyEdited(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, schedule *domain.ScheduledBroadcast, err error) error {
+	switch {
+	case errors.Is(err, broadcast.ErrScheduleNotFound):
+		return sendHTML(bot, msg.Chat.ID, tr.T("schedule.not_found"), nil)
+	case errors.Is(err, broadcast.ErrScheduleFinished):
+		return sendHTML(bot, msg.Chat.ID, tr.T("schedule.finished"), nil)
+	case err != nil:
+		return err
+	}
+
+	text, markup := h.renderCard(tr, schedule)
+	return sendHTML(bot, msg.Chat.ID, tr.T("schedule.updated")+"\n\n"+text, markup)
+}
+
+// HandleCallback обрабатывает кнопки списка и карточки рассылки
+func (h *ScheduleHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	tr := h.loc.For(callback.From)
+	if !h.admins.IsAdmin(callback.From.ID) {
+		return AnswerCallbackAlert(bot, callback, tr.T("auth.forbidden"))
+	}
+	if callback.Message == nil {
+		return AnswerCallback(bot, callback, "")
+	}
+
+	ctx := context.Background()
+
+	// Данные кнопки: "sched:list" или "sched:<действие>:<id>"
+	action, value, _ := strings.Cut(strings.TrimPrefix(callback.Data, schedulePrefix+":"), ":")
+	if action == scheduleActionList {
+		text, markup, err := h.renderList(ctx, tr)
+		if err != nil {
+			return err
+		}
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return EditCallbackMessageHTML(bot, callback, text, markup)
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("неверные данные кнопки рассылки по расписанию: %q", callback.Data)
+	}
+	schedule, err := h.scheduler.Get(ctx, id)
+	if errors.Is(err, broadcast.ErrScheduleNotFound) {
+		return AnswerCallbackAlert(bot, callback, tr.T("schedule.not_found"))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Кнопки и сценарии отвечают администратору в том же чате
+	msg := &tgbotapi.Message{From: callback.From, Chat: callback.Message.Chat}
+
+	switch action {
+	case scheduleActionOpen:
+		return h.showCard(bot, callback, tr, schedule)
+
+	case scheduleActionPreview:
+		if _, err := bot.Request(broadcast.Message(schedule.Broadcast(), msg.Chat.ID)); err != nil {
+			return AnswerCallbackAlert(bot, callback, tr.T("schedule.preview_failed"))
+		}
+		return AnswerCallback(bot, callback, "")
+	}
+
+	if !schedule.IsActive() {
+		if err := AnswerCallbackAlert(bot, callback, tr.T("schedule.finished")); err != nil {
+			return err
+		}
+		return h.showCard(bot, callback, tr, schedule)
+	}
+
+	switch action {
+	case scheduleActionRecurrence:
+		edited, err := h.scheduler.Edit(ctx, id, func(schedule *domain.ScheduledBroadcast) {
+			schedule.Recurrence = nextRecurrence(schedule.Recurrence)
+		})
+		if err != nil && !errors.Is(err, broadcast.ErrScheduleFinished) {
+			return err
+		}
+		return h.showCard(bot, callback, tr, edited)
+
+	case scheduleActionTime:
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return h.askTime(bot, msg, tr, schedule)
+
+	case scheduleActionAudience, scheduleActionMessage:
+		step, prompt := stepScheduleAudience, tr.T("schedule.ask_audience")
+		if action == scheduleActionMessage {
+			step, prompt = stepScheduleMessage, tr.T("schedule.ask_message")
+		}
+		sess := h.sessions.Start(callback.From.ID, scheduleFlow, step)
+		sess.Data[scheduleIDKey] = strconv.FormatInt(id, 10)
+		h.sessions.Save(callback.From.ID, sess)
+
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		return sendHTML(bot, msg.Chat.ID, prompt, nil)
+
+	case scheduleActionCancel:
+		if err := AnswerCallback(bot, callback, ""); err != nil {
+			return err
+		}
+		question := tr.T("schedule.confirm_cancel", i18n.Args{"id": id})
+		return h.confirm.Ask(bot, msg, question, scheduleTTL, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) (string, error) {
+			tr := h.loc.For(callback.From)
+			cancelled, err := h.scheduler.Cancel(context.Background(), id)
+			if err != nil {
+				return "", err
+			}
+			if !cancelled {
+				return tr.T("schedule.finished"), nil
+			}
+			return tr.T("schedule.cancelled", i18n.Args{"id": id}), nil
+		})
+
+	default:
+		return AnswerCallback(bot, callback, "")
+	}
+}
+
+// askTime спрашивает новое время отправки запланированной рассылки
+func (h *ScheduleHandler) askTime(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, schedule *domain.ScheduledBroadcast) error {
+	opts := DatePickerOptions{
+		Location: schedule.Location(),
+		Min:      time.Now(),
+		WithTime: true,
+	}
+	return h.datePicker.Ask(bot, msg, tr.T("schedule.ask_time"), opts, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, value time.Time) (string, error) {
+		tr := h.loc.For(callback.From)
+
+		edited, err := h.scheduler.Edit(context.Background(), schedule.ID, func(schedule *domain.ScheduledBroadcast) {
+			schedule.NextRunAt = value.UTC()
+		})
+		switch {
+		case errors.Is(err, broadcast.ErrScheduleInPast):
+			return tr.T("schedule.past"), h.askTime(bot, msg, tr, schedule)
+		case errors.Is(err, broadcast.ErrScheduleNotFound):
+			return tr.T("schedule.not_found"), nil
+		case errors.Is(err, broadcast.ErrScheduleFinished):
+			return tr.T("schedule.finished"), nil
+		case err != nil:
+			return "", err
+		}
+
+		text, markup := h.renderCard(tr, edited)
+		if err := sendHTML(bot, msg.Chat.ID, tr.T("schedule.updated")+"\n\n"+text, markup); err != nil {
+			return "", err
+		}
+		return tr.T("schedule.time_set", i18n.Args{"time": scheduleTime(tr, edited)}), nil
+	})
+}
+
+// showCard заменяет сообщение с кнопкой карточкой рассылки
+func (h *ScheduleHandler) showCard(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, tr *i18n.Translator, schedule *domain.ScheduledBroadcast) error {
+	text, markup := h.renderCard(tr, schedule)
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	return EditCallbackMessageHTML(bot, callback, text, markup)
+}
+
+// renderList формирует список запланированных рассылок с кнопкой для каждой
+func (h *ScheduleHandler) renderList(ctx context.Context, tr *i18n.Translator) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	schedules, err := h.scheduler.List(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(schedules) == 0 {
+		return tr.T("schedule.empty"), nil, nil
+	}
+
+	var (
+		text strings.Builder
+		rows [][]tgbotapi.InlineKeyboardButton
+	)
+	text.WriteString(tr.T("schedule.title") + "\n\n")
+	for _, schedule := range schedules {
+		fmt.Fprintf(&text, "• #%d — %s, %s\n", schedule.ID, scheduleTime(tr, &schedule), recurrenceText(tr, schedule.Recurrence))
+
+		label := fmt.Sprintf("#%d · %s", schedule.ID, tr.DateTime(schedule.NextRunAt.In(schedule.Location())))
+		data := fmt.Sprintf("%s:%s:%d", schedulePrefix, scheduleActionOpen, schedule.ID)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, data)))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text.String(), &markup, nil
+}
+
+// renderCard формирует карточку рассылки: когда, как часто и кому она уйдёт, и кнопки изменения
+func (h *ScheduleHandler) renderCard(tr *i18n.Translator, schedule *domain.ScheduledBroadcast) (string, *tgbotapi.InlineKeyboardMarkup) {
+	var text strings.Builder
+	text.WriteString(tr.T("schedule.card", i18n.Args{
+		"id":         schedule.ID,
+		"time":       scheduleTime(tr, schedule),
+		"recurrence": recurrenceText(tr, schedule.Recurrence),
+		"audience":   describeAudience(tr, schedule.Audience, schedule.Location()),
+	}))
+	if schedule.LastBroadcastID != 0 {
+		text.WriteString("\n" + tr.T("schedule.last_run", i18n.Args{"broadcast": schedule.LastBroadcastID}))
+	}
+	for _, status := range scheduleStatuses {
+		if status.status == schedule.Status {
+			text.WriteString("\n\n" + tr.T(status.key))
+		}
+	}
+
+	button := func(label, action string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s:%s:%d", schedulePrefix, action, schedule.ID))
+	}
+	back := tgbotapi.NewInlineKeyboardButtonData(tr.T("schedule.button.back"), schedulePrefix+":"+scheduleActionList)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if schedule.IsActive() {
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				button(tr.T("schedule.button.time"), scheduleActionTime),
+				button(tr.T("schedule.button.recurrence", i18n.Args{"recurrence": recurrenceText(tr, schedule.Recurrence)}), scheduleActionRecurrence),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				button(tr.T("schedule.button.audience"), scheduleActionAudience),
+				button(tr.T("schedule.button.message"), scheduleActionMessage),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				button(tr.T("schedule.button.preview"), scheduleActionPreview),
+				button(tr.T("schedule.button.cancel"), scheduleActionCancel),
+			),
+		)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(back))
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text.String(), &markup
+}
+
+// scheduleTime описывает время следующей отправки в часовом поясе рассылки
+func scheduleTime(tr *i18n.Translator, schedule *domain.ScheduledBroadcast) string {
+	location := schedule.Location()
+	return tr.T("schedule.time", i18n.Args{
+		"time": tr.DateTime(schedule.NextRunAt.In(location)),
+		"zone": location.String(),
+	})
+}
+
+// recurrenceText возвращает название повтора
+func recurrenceText(tr *i18n.Translator, recurrence string) string {
+	for _, option := range scheduleRecurrences {
+		if option.value == recurrence {
+			return tr.T(option.key)
+		}
+	}
+	return recurrence
+}
+
+// nextRecurrence возвращает повтор, следующий за recurrence при переключении кнопкой
+func nextRecurrence(recurrence string) string {
+	for i, option := range scheduleRecurrences {
+		if option.value == recurrence {
+			return scheduleRecurrences[(i+1)%len(scheduleRecurrences)].value
+		}
+	}
+	return domain.RecurrenceNone
+}
+
+// sendHTML отправляет HTML-сообщение с инлайн-клавиатурой (markup == nil — без неё)
+func sendHTML(bot *tgbotapi.BotAPI, chatID int64, text string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	reply := tgbotapi.NewMessage(chatID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	if markup != nil {
+		reply.ReplyMarkup = *markup
+	}
+	_, err := bot.Send(reply)
+	return err
+}
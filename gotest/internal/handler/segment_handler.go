@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/broadcast"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/keyboard"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// SegmentHandler обрабатывает команду /segment — сохранённые аудитории рассылок:
+//
+//	/segment                         — список сегментов
+//	/segment count <сегмент|условия> — сколько получателей у аудитории
+//	/segment save <имя> <условия>    — сохранить сегмент (или заменить сегмент с тем же именем)
+//	/segment chats <имя>             — выбрать типы чатов сегмента кнопками
+//	/segment delete <имя>            — удалить сегмент
+type SegmentHandler struct {
+	segments   repository.SegmentRepository
+	broadcasts *broadcast.Service
+	settings   *settings.Service
+	checklist  *Checklist
+	admins     middleware.AdminChecker
+	loc        *i18n.Localizer
+}
+
+// NewSegmentHandler создаёт новый обработчик команды /segment
+func NewSegmentHandler(segments repository.SegmentRepository, broadcasts *broadcast.Service, settings *settings.Service, checklist *Checklist, admins middleware.AdminChecker, loc *i18n.Localizer) *SegmentHandler {
+	return &SegmentHandler{
+		segments:   segments,
+		broadcasts: broadcasts,
+		settings:   settings,
+		checklist:  checklist,
+		admins:     admins,
+		loc:        loc,
+	}
+}
+
+// Command возвращает команду
+func (h *SegmentHandler) Command() string {
+	return "segment"
+}
+
+// Handle выполняет действие с сегментами
+func (h *SegmentHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	ctx := context.Background()
+	location := h.settings.Location(msg.From.ID)
+	action, args, _ := strings.Cut(strings.TrimSpace(msg.CommandArguments()), " ")
+	args = strings.TrimSpace(args)
+
+	var (
+		text string
+		err  error
+	)
+	switch {
+	case action == "" || action == "list":
+		text, err = h.list(ctx, tr, location)
+	case action == "count" && args != "":
+		text, err = h.count(ctx, tr, args, location)
+	case action == "save" && args != "":
+		name, conditions, _ := strings.Cut(args, " ")
+		text, err = h.save(ctx, tr, msg.From.ID, strings.ToLower(name), conditions, location)
+	case action == "chats" && args != "":
+		return h.askChats(ctx, bot, msg, tr, strings.ToLower(args), location)
+	case action == "delete" && args != "":
+		text, err = h.delete(ctx, tr, strings.ToLower(args))
+	default:
+		text = tr.T("segment.usage")
+	}
+	if err != nil {
+		return err
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	_, err = bot.Send(reply)
+	return err
+}
+
+// list перечисляет сохранённые сегменты
+func (h *SegmentHandler) list(ctx context.Context, tr *i18n.Translator, location *time.Location) (string, error) {
+	segments, err := h.segments.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("ошибка чтения сегментов: %w", err)
+	}
+	if len(segments) == 0 {
+		return tr.T("segment.empty"), nil
+	}
+
+	var text strings.Builder
+	text.WriteString(tr.T("segment.title") + "\n\n")
+	for _, segment := range segments {
+		fmt.Fprintf(&text, "• <b>%s</b> — %s\n", segment.Name, describeAudience(tr, segment.Audience, location))
+	}
+	return text.String(), nil
+}
+
+// count считает получателей аудитории: сегмента или условий
+func (h *SegmentHandler) count(ctx context.Context, tr *i18n.Translator, spec string, location *time.Location) (string, error) {
+	audience, err := h.broadcasts.Audience(ctx, spec, location)
+	if text, ok := audienceErrorText(tr, err); ok {
+		return text, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	recipients, err := h.broadcasts.Recipients(ctx, audience)
+	if err != nil {
+		return "", err
+	}
+	return tr.T("segment.count", i18n.Args{
+		"audience":   describeAudience(tr, audience, location),
+		"recipients": tr.N("broadcast.recipients", int64(len(recipients))),
+	}), nil
+}
+
+// save сохраняет сегмент и показывает, сколько у него получателей
+func (h *SegmentHandler) save(ctx context.Context, tr *i18n.Translator, adminID int64, name, conditions string, location *time.Location) (string, error) {
+	if !broadcast.ValidSegmentName(name) {
+		return tr.T("segment.invalid_name"), nil
+	}
+
+	audience, err := broadcast.ParseAudience(conditions, location)
+	if text, ok := audienceErrorText(tr, err); ok {
+		return text, nil
+	}
+
+	segment := &domain.Segment{
+		Name:      name,
+		Audience:  audience,
+		CreatedBy: adminID,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := h.segments.Save(ctx, segment); err != nil {
+		return "", fmt.Errorf("ошибка сохранения сегмента: %w", err)
+	}
+
+	recipients, err := h.broadcasts.Recipients(ctx, audience)
+	if err != nil {
+		return "", err
+	}
+	return tr.T("segment.saved", i18n.Args{
+		"name":       name,
+		"audience":   describeAudience(tr, audience, location),
+		"recipients": tr.N("broadcast.recipients", int64(len(recipients))),
+	}), nil
+}
+
+// askChats показывает типы чатов сегмента списком с отметками.
+// Выбор сохраняется в сегменте, когда администратор нажимает "Готово"
+func (h *SegmentHandler) askChats(ctx context.Context, bot *tgbotapi.BotAPI, msg *tgbotapi.Message, tr *i18n.Translator, name string, location *time.Location) error {
+	segment, err := h.segments.Get(ctx, name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return sendHTML(bot, msg.Chat.ID, tr.T("segment.not_found", i18n.Args{"name": html.EscapeString(name)}), nil)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка чтения сегмента: %w", err)
+	}
+
+	options := []keyboard.ChecklistOption{
+		{Key: domain.ChatTypePrivate, Label: tr.T("segment.chat_type.private")},
+		{Key: domain.ChatTypeGroup, Label: tr.T("segment.chat_type.group")},
+		{Key: domain.ChatTypeSupergroup, Label: tr.T("segment.chat_type.supergroup")},
+		{Key: domain.ChatTypeChannel, Label: tr.T("segment.chat_type.channel")},
+	}
+	selected := segment.Audience.ChatTypes
+	if len(selected) == 0 {
+		// Без условия chats рассылка уходит только в личные чаты
+		selected = []string{domain.ChatTypePrivate}
+	}
+
+	question := tr.T("segment.chats_prompt", i18n.Args{"name": name})
+	return h.checklist.Ask(bot, msg, question, options, selected, func(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery, chatTypes []string) (string, error) {
+		return h.saveChats(context.Background(), h.loc.For(callback.From), callback.From.ID, name, chatTypes, location)
+	})
+}
+
+// saveChats заменяет типы чатов сегмента выбранными в списке
+func (h *SegmentHandler) saveChats(ctx context.Context, tr *i18n.Translator, adminID int64, name string, chatTypes []string, location *time.Location) (string, error) {
+	// Права могли отозвать, пока список был открыт
+	if !h.admins.IsAdmin(adminID) {
+		return tr.T("auth.forbidden"), nil
+	}
+	if len(chatTypes) == 0 {
+		return tr.T("segment.chats_empty"), nil
+	}
+
+	// Сегмент перечитывается: пока список был открыт, его могли изменить или удалить
+	segment, err := h.segments.Get(ctx, name)
+	if errors.Is(err, repository.ErrNotFound) {
+		return tr.T("segment.not_found", i18n.Args{"name": html.EscapeString(name)}), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("ошибка чтения сегмента: %w", err)
+	}
+
+	segment.Audience.ChatTypes = chatTypes
+	segment.CreatedBy = adminID
+	segment.UpdatedAt = time.Now().UTC()
+	if err := h.segments.Save(ctx, segment); err != nil {
+		return "", fmt.Errorf("ошибка сохранения сегмента: %w", err)
+	}
+
+	recipients, err := h.broadcasts.Recipients(ctx, segment.Audience)
+	if err != nil {
+		return "", err
+	}
+	return tr.T("segment.saved", i18n.Args{
+		"name":       name,
+		"audience":   describeAudience(tr, segment.Audience, location),
+		"recipients": tr.N("broadcast.recipients", int64(len(recipients))),
+	}), nil
+}
+
+// delete удаляет сегмент
+func (h *SegmentHandler) delete(ctx context.Context, tr *i18n.Translator, name string) (string, error) {
+	deleted, err := h.segments.Delete(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("ошибка удаления сегмента: %w", err)
+	}
+	if !deleted {
+		return tr.T("segment.not_found", i18n.Args{"name": html.EscapeString(name)}), nil
+	}
+	return tr.T("segment.deleted", i18n.Args{"name": name}), nil
+}
+
+// describeAudience описывает аудиторию для администратора: условия в том виде,
+// в каком их можно передать /broadcast, или "все подписчики", если условий нет
+func describeAudience(tr *i18n.Translator, audience domain.Audience, location *time.Location) string {
+	if conditions := broadcast.FormatAudience(audience, location); conditions != "" {
+		return "<code>" + conditions + "</code>"
+	}
+	return tr.T("segment.everyone")
+}
+
+// audienceErrorText возвращает понятный администратору текст ошибки выбора аудитории.
+// ok == false — ошибки нет или она не связана с тем, что ввёл администратор
+func audienceErrorText(tr *i18n.Translator, err error) (string, bool) {
+	var invalid *broadcast.AudienceError
+	switch {
+	case errors.As(err, &invalid):
+		return tr.T("segment.invalid", i18n.Args{"condition": html.EscapeString(invalid.Condition)}), true
+	case errors.Is(err, broadcast.ErrSegmentNotFound):
+		return tr.T("segment.unknown"), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/settings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// settingsPrefix — префикс callback-данных экрана настроек
+const settingsPrefix = "set"
+
+// Разделы экрана настроек в callback-данных: "set:lang:en", "set:tz:Europe/Moscow", "set:quiet:23-8"
+const (
+	settingsHome   = "home"   // Главный экран настроек
+	settingsLang   = "lang"   // Язык интерфейса
+	settingsNotify = "notify" // Переключить уведомления
+	settingsTZ     = "tz"     // Часовой пояс
+	settingsQuiet  = "quiet"  // Тихие часы
+)
+
+// settingsAutoLanguage — значение "язык как в Telegram"
+const settingsAutoLanguage = "auto"
+
+// settingsTimeZones — часовые пояса, которые можно выбрать кнопками
+var settingsTimeZones = []string{
+	"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg",
+	"Asia/Omsk", "Asia/Novosibirsk", "Asia/Krasnoyarsk", "Asia/Irkutsk",
+	"Asia/Yakutsk", "Asia/Vladivostok", "Asia/Magadan", "Asia/Kamchatka",
+	"Europe/London", "Europe/Berlin", "America/New_York", "UTC",
+}
+
+// settingsQuietHours — варианты тихих часов [начало, конец]
+var settingsQuietHours = [][2]int{{22, 7}, {23, 8}, {0, 9}, {1, 10}}
+
+// SettingsHandler обрабатывает команду /settings и кнопки экрана настроек
+type SettingsHandler struct {
+	loc      *i18n.Localizer
+	settings *settings.Service
+}
+
+// NewSettingsHandler создаёт новый обработчик команды /settings
+func NewSettingsHandler(loc *i18n.Localizer, settings *settings.Service) *SettingsHandler {
+	return &SettingsHandler{
+		loc:      loc,
+		settings: settings,
+	}
+}
+
+// Command возвращает команду
+func (h *SettingsHandler) Command() string {
+	return "settings"
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *SettingsHandler) Prefix() string {
+	return settingsPrefix
+}
+
+// Handle обрабатывает команду /settings — показывает текущие настройки
+func (h *SettingsHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	text, markup := h.home(h.loc.For(msg.From), msg.From)
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = markup
+	_, err := bot.Send(reply)
+	return err
+}
+
+// HandleCallback обрабатывает кнопки экрана настроек
+func (h *SettingsHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	payload := strings.TrimPrefix(callback.Data, settingsPrefix+":")
+	section, value, hasValue := strings.Cut(payload, ":")
+	userID := callback.From.ID
+	tr := h.loc.For(callback.From)
+
+	var (
+		text   string
+		markup tgbotapi.InlineKeyboardMarkup
+		err    error
+	)
+
+	switch {
+	case section == settingsLang && hasValue:
+		lang := value
+		if lang == settingsAutoLanguage {
+			lang = ""
+		} else if !h.loc.Bundle().Supports(lang) {
+			return AnswerCallback(bot, callback, "")
+		}
+		err = h.settings.SetLanguage(userID, lang)
+
+	case section == settingsLang:
+		text, markup = h.languages(tr)
+
+	case section == settingsNotify:
+		current := h.settings.Get(userID)
+		err = h.settings.SetNotifications(userID, !current.NotificationsEnabled)
+
+	case section == settingsTZ && hasValue:
+		if !slices.Contains(settingsTimeZones, value) {
+			return AnswerCallback(bot, callback, "")
+		}
+		err = h.settings.SetTimeZone(userID, value)
+
+	case section == settingsTZ:
+		text, markup = h.timeZones(tr)
+
+	case section == settingsQuiet && hasValue:
+		start, end, ok := parseQuietHours(value)
+		if !ok {
+			return AnswerCallback(bot, callback, "")
+		}
+		err = h.settings.SetQuietHours(userID, start, end)
+
+	case section == settingsQuiet:
+		text, markup = h.quietHours(tr)
+	}
+
+	if err != nil {
+		_ = AnswerCallback(bot, callback, tr.T("settings.failed"))
+		return err
+	}
+
+	toast := ""
+	if text == "" {
+		// Настройка изменена (или нажата кнопка "Назад") — возвращаемся на главный экран.
+		// Переводчик берём заново: пользователь мог сменить язык
+		if hasValue || section == settingsNotify {
+			toast = h.loc.For(callback.From).T("settings.saved")
+		}
+		text, markup = h.home(h.loc.For(callback.From), callback.From)
+	}
+
+	if err := AnswerCallback(bot, callback, toast); err != nil {
+		return err
+	}
+	return EditCallbackMessageHTML(bot, callback, text, &markup)
+}
+
+// home формирует главный экран настроек
+func (h *SettingsHandler) home(tr *i18n.Translator, user *tgbotapi.User) (string, tgbotapi.InlineKeyboardMarkup) {
+	current := h.settings.Get(user.ID)
+
+	language := tr.T("language.name")
+	if current.Language == "" {
+		language = tr.T("settings.language_auto", i18n.Args{"language": language})
+	}
+
+	state := tr.T("settings.off")
+	if current.NotificationsEnabled {
+		state = tr.T("settings.on")
+	}
+
+	text := fmt.Sprintf("<b>%s</b>\n\n%s", tr.T("settings.title"), tr.T("settings.summary", i18n.Args{
+		"language":      language,
+		"notifications": state,
+		"time_zone":     timeZoneLabel(current.Location()),
+		"quiet":         quietHoursLabel(tr, current),
+	}))
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			settingsButton(tr.T("settings.button.language"), settingsLang),
+			settingsButton(tr.T("settings.button.notifications", i18n.Args{"state": state}), settingsNotify),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			settingsButton(tr.T("settings.button.time_zone"), settingsTZ),
+			settingsButton(tr.T("settings.button.quiet"), settingsQuiet),
+		),
+	)
+	return text, markup
+}
+
+// languages формирует экран выбора языка
+func (h *SettingsHandler) languages(tr *i18n.Translator) (string, tgbotapi.InlineKeyboardMarkup) {
+	bundle := h.loc.Bundle()
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, lang := range bundle.Languages() {
+		label := bundle.Translator(lang).T("language.name")
+		row = append(row, settingsButton(label, settingsLang+":"+lang))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(settingsButton(tr.T("settings.button.language_auto"), settingsLang+":"+settingsAutoLanguage)),
+		tgbotapi.NewInlineKeyboardRow(settingsButton(tr.T("button.back"), settingsHome)),
+	)
+	return tr.T("settings.choose_language"), markup
+}
+
+// timeZones формирует экран выбора часового пояса (по две кнопки в ряду)
+func (h *SettingsHandler) timeZones(tr *i18n.Translator) (string, tgbotapi.InlineKeyboardMarkup) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, name := range settingsTimeZones {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Неизвестный часовой пояс %s: %v", name, err)
+			continue
+		}
+
+		button := settingsButton(timeZoneLabel(location), settingsTZ+":"+name)
+		if i%2 == 0 || len(rows) == 0 {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(settingsButton(tr.T("button.back"), settingsHome)))
+
+	return tr.T("settings.choose_time_zone"), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// quietHours формирует экран выбора тихих часов
+func (h *SettingsHandler) quietHours(tr *i18n.Translator) (string, tgbotapi.InlineKeyboardMarkup) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, hours := range settingsQuietHours {
+		label := formatQuietHours(hours[0], hours[1])
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			settingsButton("🌙 "+label, fmt.Sprintf("%s:%d-%d", settingsQuiet, hours[0], hours[1])),
+		))
+	}
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(settingsButton(tr.T("settings.button.quiet_off"), settingsQuiet+":0-0")),
+		tgbotapi.NewInlineKeyboardRow(settingsButton(tr.T("button.back"), settingsHome)),
+	)
+
+	return tr.T("settings.choose_quiet"), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// settingsButton создаёт кнопку с данными "set:<действие>"
+func settingsButton(text, action string) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(text, settingsPrefix+":"+action)
+}
+
+// timeZoneLabel подписывает часовой пояс смещением: "Europe/Moscow (UTC+03:00)"
+func timeZoneLabel(location *time.Location) string {
+	return fmt.Sprintf("%s (UTC%s)", location, time.Now().In(location).Format("-07:00"))
+}
+
+// quietHoursLabel описывает тихие часы пользователя
+func quietHoursLabel(tr *i18n.Translator, current domain.UserSettings) string {
+	if !current.HasQuietHours() {
+		return tr.T("settings.off")
+	}
+	return formatQuietHours(current.QuietStart, current.QuietEnd)
+}
+
+// formatQuietHours форматирует интервал тихих часов: "23:00–08:00"
+func formatQuietHours(start, end int) string {
+	return fmt.Sprintf("%02d:00–%02d:00", start, end)
+}
+
+// parseQuietHours разбирает интервал из callback-данных: "23-8"
+func parseQuietHours(value string) (start, end int, ok bool) {
+	first, second, found := strings.Cut(value, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, errStart := strconv.Atoi(first)
+	end, errEnd := strconv.Atoi(second)
+	if errStart != nil || errEnd != nil || start < 0 || start > 23 || end < 0 || end > 23 {
+		return 0, 0, false
+	}
+	return start, end, true
+}
@@ -1,17 +1,21 @@
 package handler
 
 import (
-	"telegram-bot/internal/keyboard"
+	"telegram-bot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 // StartHandler обрабатывает команду /start
-type StartHandler struct{}
+type StartHandler struct {
+	loc *i18n.Localizer
+}
 
 // NewStartHandler создаёт новый обработчик команды /start
-func NewStartHandler() *StartHandler {
-	return &StartHandler{}
+func NewStartHandler(loc *i18n.Localizer) *StartHandler {
+	return &StartHandler{
+		loc: loc,
+	}
 }
 
 // Command возвращает команду, которую обрабатывает этот обработчик
@@ -22,21 +26,10 @@ func (h *StartHandler) Command() string {
 // Handle обрабатывает команду /start
 func (h *StartHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
 	chatID := msg.Chat.ID
+	tr := h.loc.For(msg.From)
 
-	text := "Привет! Я тестовый бот на Go.\n\n" +
-		"Я могу помочь вам с различными задачами.\n\n" +
-		"Доступные команды:\n" +
-		"/start - начать работу\n" +
-		"/help - помощь\n" +
-		"/info - информация о вас"
-
-	reply := tgbotapi.NewMessage(chatID, text)
-
-	// Прикрепляем инлайн-клавиатуру к сообщению
-	// reply.ReplyMarkup = keyboard.NewLanguageKeyboard()
-	// reply.ReplyMarkup = keyboard.NewMainMenuKeyboard()
-	reply.ReplyMarkup = keyboard.NewConfirmKeyboard("delete_profile")
-	bot.Send(reply)
+	// Кнопок удаления под приветствием нет: удаление подтверждается только в /deleteme
+	reply := tgbotapi.NewMessage(chatID, tr.T("start.welcome")+"\n\n"+tr.T("start.delete_prompt"))
 
 	_, err := bot.Send(reply)
 	return err
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/middleware"
+	"telegram-bot/internal/settings"
+	"telegram-bot/internal/stats"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// statsPrefix — префикс callback-данных кнопок выбора периода: "stats:7d"
+const statsPrefix = "stats"
+
+// statsPeriods — периоды в порядке кнопок и ключи их названий в каталогах
+var statsPeriods = []struct {
+	period stats.Period
+	key    string
+}{
+	{period: stats.PeriodToday, key: "stats.period.today"},
+	{period: stats.PeriodWeek, key: "stats.period.week"},
+	{period: stats.PeriodMonth, key: "stats.period.month"},
+}
+
+// StatsHandler обрабатывает команду /stats — статистику бота для администраторов
+type StatsHandler struct {
+	stats    *stats.Service
+	settings *settings.Service
+	admins   middleware.AdminChecker
+	loc      *i18n.Localizer
+}
+
+// NewStatsHandler создаёт новый обработчик команды /stats
+func NewStatsHandler(stats *stats.Service, settings *settings.Service, admins middleware.AdminChecker, loc *i18n.Localizer) *StatsHandler {
+	return &StatsHandler{
+		stats:    stats,
+		settings: settings,
+		admins:   admins,
+		loc:      loc,
+	}
+}
+
+// Command возвращает команду
+func (h *StatsHandler) Command() string {
+	return "stats"
+}
+
+// Prefix возвращает префикс callback-данных
+func (h *StatsHandler) Prefix() string {
+	return statsPrefix
+}
+
+// Handle показывает статистику за сегодня
+func (h *StatsHandler) Handle(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) error {
+	tr := h.loc.For(msg.From)
+	if !middleware.RequireAdmin(bot, msg, h.admins, tr) {
+		return nil // Сообщение уже отправлено middleware
+	}
+
+	text, markup, err := h.render(tr, msg.From.ID, stats.PeriodToday)
+	if err != nil {
+		return err
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = tgbotapi.ModeHTML
+	reply.ReplyMarkup = markup
+	_, err = bot.Send(reply)
+	return err
+}
+
+// HandleCallback пересчитывает статистику за выбранный период
+func (h *StatsHandler) HandleCallback(bot *tgbotapi.BotAPI, callback *tgbotapi.CallbackQuery) error {
+	tr := h.loc.For(callback.From)
+	if !h.admins.IsAdmin(callback.From.ID) {
+		return AnswerCallbackAlert(bot, callback, tr.T("auth.forbidden"))
+	}
+
+	period := stats.Period(strings.TrimPrefix(callback.Data, statsPrefix+":"))
+	text, markup, err := h.render(tr, callback.From.ID, period)
+	if err != nil {
+		_ = AnswerCallback(bot, callback, tr.T("stats.failed"))
+		return err
+	}
+
+	if err := AnswerCallback(bot, callback, ""); err != nil {
+		return err
+	}
+	return EditCallbackMessageHTML(bot, callback, text, &markup)
+}
+
+// render собирает отчёт и формирует текст с кнопками периодов
+func (h *StatsHandler) render(tr *i18n.Translator, adminID int64, period stats.Period) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	// "Сегодня" считается с полуночи по часовому поясу администратора
+	report, err := h.stats.Collect(context.Background(), period, h.settings.Location(adminID))
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	var (
+		title string
+		row   []tgbotapi.InlineKeyboardButton
+	)
+	for _, option := range statsPeriods {
+		label := tr.T(option.key)
+		if option.period == period {
+			title = label
+			label = "• " + label + " •"
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, statsPrefix+":"+string(option.period)))
+	}
+
+	var text strings.Builder
+	text.WriteString(tr.T("stats.title", i18n.Args{"period": title}) + "\n\n")
+
+	text.WriteString(tr.N("stats.users", int64(report.TotalUsers)) + "\n")
+	text.WriteString(tr.T("stats.new", i18n.Args{"count": tr.Number(int64(report.NewUsers))}) + "\n")
+	text.WriteString(tr.T("stats.blocked", i18n.Args{"count": tr.Number(int64(report.Blocked))}) + "\n")
+	text.WriteString(tr.T("stats.active", i18n.Args{
+		"dau": tr.Number(int64(report.DAU)),
+		"wau": tr.Number(int64(report.WAU)),
+		"mau": tr.Number(int64(report.MAU)),
+	}) + "\n\n")
+
+	text.WriteString(tr.N("stats.updates", int64(report.Updates)) + "\n")
+	text.WriteString(tr.T("stats.errors", i18n.Args{
+		"count": tr.Number(int64(report.Errors)),
+		"rate":  tr.Float(report.ErrorRate()*100, 1),
+	}) + "\n\n")
+
+	text.WriteString(tr.T("stats.top") + "\n")
+	if len(report.TopCommands) == 0 {
+		text.WriteString(tr.T("stats.no_commands") + "\n")
+	}
+	for i, command := range report.TopCommands {
+		line := fmt.Sprintf("%d. %s", i+1, tr.N("stats.command", int64(command.Count), i18n.Args{"command": command.Command}))
+		if command.Errors > 0 {
+			line += tr.T("stats.command_errors", i18n.Args{"count": tr.Number(int64(command.Errors))})
+		}
+		text.WriteString(line + "\n")
+	}
+
+	return text.String(), tgbotapi.NewInlineKeyboardMarkup(row), nil
+}
@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// locale — правила множественного числа и форматы чисел и дат одного языка
+type locale struct {
+	plural    pluralRule
+	thousands string // Разделитель разрядов
+	decimal   string // Десятичный разделитель
+	date      string // Формат даты (layout пакета time)
+	dateTime  string // Формат даты и времени
+}
+
+// locales — языки с известными правилами форматирования
+var locales = map[string]locale{
+	"ru": {
+		plural:    pluralRussian,
+		thousands: "\u00a0", // Неразрывный пробел: 1 234 567
+		decimal:   ",",
+		date:      "02.01.2006",
+		dateTime:  "02.01.2006 15:04",
+	},
+	"en": {
+		plural:    pluralEnglish,
+		thousands: ",",
+		decimal:   ".",
+		date:      "Jan 2, 2006",
+		dateTime:  "Jan 2, 2006 3:04 PM",
+	},
+}
+
+// defaultLocale используется для языков, которых нет в locales
+var defaultLocale = locale{
+	plural:    pluralOther,
+	thousands: " ",
+	decimal:   ".",
+	date:      "2006-01-02",
+	dateTime:  "2006-01-02 15:04",
+}
+
+// localeFor возвращает правила форматирования языка
+func localeFor(lang string) locale {
+	if l, exists := locales[lang]; exists {
+		return l
+	}
+	return defaultLocale
+}
+
+// Number форматирует целое число с разделителями разрядов: 1 234 567 или 1,234,567
+func (t *Translator) Number(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	return sign + t.group(digits)
+}
+
+// Float форматирует дробное число с precision знаками после запятой
+func (t *Translator) Float(f float64, precision int) string {
+	formatted := strconv.FormatFloat(f, 'f', precision, 64)
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+
+	whole, fraction, found := strings.Cut(formatted, ".")
+	if !found {
+		return sign + t.group(whole)
+	}
+	return sign + t.group(whole) + t.locale.decimal + fraction
+}
+
+// group расставляет разделители разрядов в строке цифр
+func (t *Translator) group(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(t.locale.thousands)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// Date форматирует дату: 18.10.2026 или Oct 18, 2026
+func (t *Translator) Date(value time.Time) string {
+	return value.Format(t.locale.date)
+}
+
+// DateTime форматирует дату и время: 18.10.2026 14:30 или Oct 18, 2026 2:30 PM
+func (t *Translator) DateTime(value time.Time) string {
+	return value.Format(t.locale.dateTime)
+}
+
+// durationUnits — единицы длительности от крупной к мелкой и ключи их названий в каталогах
+var durationUnits = []struct {
+	size time.Duration
+	key  string
+}{
+	{size: 24 * time.Hour, key: "unit.day"},
+	{size: time.Hour, key: "unit.hour"},
+	{size: time.Minute, key: "unit.minute"},
+	{size: time.Second, key: "unit.second"},
+}
+
+// Duration форматирует длительность двумя крупнейшими единицами: "2 дня 3 часа", "5 minutes"
+func (t *Translator) Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	parts := make([]string, 0, 2)
+	for _, unit := range durationUnits {
+		count := d / unit.size
+		if count == 0 {
+			continue
+		}
+		d -= count * unit.size
+		parts = append(parts, t.N(unit.key, int64(count)))
+		if len(parts) == 2 {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return t.N("unit.second", 0)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,264 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Args — значения именованных подстановок: {"name": "Иван"} заменяет {name} в тексте
+type Args map[string]any
+
+// message — текст одного ключа каталога: строка или формы множественного числа
+type message struct {
+	text  string            // Обычный текст
+	forms map[string]string // Карта: категория множественного числа -> текст
+}
+
+// UnmarshalJSON разбирает строку или объект {"one": "...", "few": "...", "other": "..."}
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &m.forms); err != nil {
+		return fmt.Errorf("ожидалась строка или объект с формами множественного числа: %w", err)
+	}
+	for category := range m.forms {
+		if !slices.Contains(pluralCategories, category) {
+			return fmt.Errorf("неизвестная категория множественного числа %q", category)
+		}
+	}
+	if _, exists := m.forms[PluralOther]; !exists {
+		return fmt.Errorf("нет обязательной формы %q", PluralOther)
+	}
+	return nil
+}
+
+// form возвращает текст для категории (или форму other, если такой категории нет)
+func (m message) form(category string) string {
+	if m.forms == nil {
+		return m.text
+	}
+	if text, exists := m.forms[category]; exists {
+		return text
+	}
+	return m.forms[PluralOther]
+}
+
+// Bundle — каталоги сообщений для всех языков бота.
+// Каталоги можно перечитать без перезапуска бота (Reload, Watch)
+type Bundle struct {
+	dir         string // Каталог с файлами <язык>.json
+	defaultLang string // Язык, на который переключаемся, если перевода нет
+
+	mu       sync.RWMutex
+	catalogs map[string]map[string]message // Карта: язык -> (ключ -> текст)
+}
+
+// LoadDir загружает каталоги из файлов <язык>.json в каталоге dir.
+// Каждый файл — JSON-объект "ключ": "текст"; для множественного числа
+// вместо текста указывается объект с формами {"one", "few", "many", "other"}
+func LoadDir(dir, defaultLang string) (*Bundle, error) {
+	catalogs, err := loadCatalogs(dir, defaultLang)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bundle{
+		dir:         dir,
+		defaultLang: defaultLang,
+		catalogs:    catalogs,
+	}, nil
+}
+
+// loadCatalogs читает все файлы каталогов из dir
+func loadCatalogs(dir, defaultLang string) (map[string]map[string]message, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make(map[string]map[string]message)
+	for _, path := range paths {
+		lang := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := make(map[string]message)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("ошибка разбора каталога %s: %w", path, err)
+		}
+		catalogs[lang] = catalog
+	}
+
+	if _, exists := catalogs[defaultLang]; !exists {
+		return nil, fmt.Errorf("в каталоге %s нет файла для языка по умолчанию %q", dir, defaultLang)
+	}
+
+	return catalogs, nil
+}
+
+// Reload перечитывает каталоги с диска. При ошибке остаются прежние каталоги
+func (b *Bundle) Reload() error {
+	catalogs, err := loadCatalogs(b.dir, b.defaultLang)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.catalogs = catalogs
+	b.mu.Unlock()
+	return nil
+}
+
+// DefaultLanguage возвращает язык по умолчанию
+func (b *Bundle) DefaultLanguage() string {
+	return b.defaultLang
+}
+
+// Languages возвращает список загруженных языков
+func (b *Bundle) Languages() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	langs := make([]string, 0, len(b.catalogs))
+	for lang := range b.catalogs {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// Keys возвращает отсортированные ключи каталога языка
+func (b *Bundle) Keys(lang string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	keys := make([]string, 0, len(b.catalogs[lang]))
+	for key := range b.catalogs[lang] {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Supports сообщает, есть ли каталог для языка
+func (b *Bundle) Supports(lang string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, exists := b.catalogs[lang]
+	return exists
+}
+
+// Translator возвращает переводчик для языка (неизвестный язык заменяется языком по умолчанию)
+func (b *Bundle) Translator(lang string) *Translator {
+	if !b.Supports(lang) {
+		lang = b.defaultLang
+	}
+	return &Translator{lang: lang, bundle: b, locale: localeFor(lang)}
+}
+
+// All возвращает перевод ключа на всех языках — например, чтобы
+// распознать нажатие на кнопку независимо от языка пользователя
+func (b *Bundle) All(key string) []string {
+	langs := b.Languages()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	texts := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		if msg, exists := b.catalogs[lang][key]; exists {
+			texts = append(texts, msg.form(PluralOther))
+		}
+	}
+	return texts
+}
+
+// lookup ищет текст в каталоге языка, затем в каталоге по умолчанию
+func (b *Bundle) lookup(lang, key string) (message, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if msg, exists := b.catalogs[lang][key]; exists {
+		return msg, true
+	}
+	msg, exists := b.catalogs[b.defaultLang][key]
+	return msg, exists
+}
+
+// Translator переводит ключи сообщений на один язык
+type Translator struct {
+	lang   string
+	bundle *Bundle
+	locale locale // Правила множественного числа и форматы чисел и дат
+}
+
+// Language возвращает язык переводчика
+func (t *Translator) Language() string {
+	return t.lang
+}
+
+// T возвращает текст по ключу и подставляет именованные значения: {name}.
+// Если ключа нет ни в одном каталоге, возвращается сам ключ — так в меню
+// и других настройках можно писать как ключи, так и готовый текст
+func (t *Translator) T(key string, args ...Args) string {
+	msg, exists := t.bundle.lookup(t.lang, key)
+	if !exists {
+		return t.substitute(key, args)
+	}
+	return t.substitute(msg.form(PluralOther), args)
+}
+
+// N возвращает форму текста для числа count по правилам множественного числа языка.
+// Число подставляется вместо {count} с разделителями разрядов
+func (t *Translator) N(key string, count int64, args ...Args) string {
+	all := append([]Args{{"count": t.Number(count)}}, args...)
+
+	msg, exists := t.bundle.lookup(t.lang, key)
+	if !exists {
+		return t.substitute(key, all)
+	}
+	return t.substitute(msg.form(t.locale.plural(count)), all)
+}
+
+// placeholderPattern — именованная подстановка вида {name}
+var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// substitute заменяет {name} значениями из args. Неизвестные подстановки остаются как есть.
+// Даты и длительности форматируются по правилам языка, остальное — через fmt.Sprint
+func (t *Translator) substitute(text string, args []Args) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		for _, set := range args {
+			value, exists := set[name]
+			if !exists {
+				continue
+			}
+			switch value := value.(type) {
+			case time.Time:
+				return t.DateTime(value)
+			case time.Duration:
+				return t.Duration(value)
+			default:
+				return fmt.Sprint(value)
+			}
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"log"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// LanguageStore хранит язык, который пользователь выбрал сам
+type LanguageStore interface {
+	Language(userID int64) (string, error)       // Пустая строка — язык не выбран
+	SetLanguage(userID int64, lang string) error // Сохраняет выбор пользователя
+}
+
+// Localizer выбирает язык пользователя и выдаёт переводчик для него.
+// Порядок выбора: сохранённый выбор, язык клиента Telegram, язык по умолчанию
+type Localizer struct {
+	bundle *Bundle
+	store  LanguageStore
+}
+
+// NewLocalizer создаёт новый локализатор
+func NewLocalizer(bundle *Bundle, store LanguageStore) *Localizer {
+	return &Localizer{
+		bundle: bundle,
+		store:  store,
+	}
+}
+
+// Bundle возвращает каталоги сообщений
+func (l *Localizer) Bundle() *Bundle {
+	return l.bundle
+}
+
+// For возвращает переводчик для пользователя Telegram
+func (l *Localizer) For(user *tgbotapi.User) *Translator {
+	return l.bundle.Translator(l.Resolve(user))
+}
+
+// Resolve определяет язык пользователя
+func (l *Localizer) Resolve(user *tgbotapi.User) string {
+	if user == nil {
+		return l.bundle.DefaultLanguage()
+	}
+
+	// 1. Язык, выбранный пользователем (в /settings или кнопками lang_ru/lang_en)
+	lang, err := l.store.Language(user.ID)
+	if err != nil {
+		log.Printf("Ошибка чтения языка пользователя %d: %v", user.ID, err)
+	}
+	if l.bundle.Supports(lang) {
+		return lang
+	}
+
+	// 2. Язык интерфейса Telegram: "en-US" -> "en"
+	code, _, _ := strings.Cut(strings.ToLower(user.LanguageCode), "-")
+	if l.bundle.Supports(code) {
+		return code
+	}
+
+	// 3. Язык по умолчанию
+	return l.bundle.DefaultLanguage()
+}
+
+// SetLanguage сохраняет выбранный пользователем язык
+func (l *Localizer) SetLanguage(userID int64, lang string) error {
+	return l.store.SetLanguage(userID, lang)
+}
@@ -0,0 +1,49 @@
+package i18n
+
+// Категории множественного числа CLDR
+// (https://cldr.unicode.org/index/cldr-spec/plural-rules)
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other" // Обязательная форма, используется, если нужной нет
+)
+
+// pluralCategories — все допустимые категории в каталогах
+var pluralCategories = []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// pluralRule выбирает категорию множественного числа для целого числа
+type pluralRule func(n int64) string
+
+// pluralRussian — правило для русского языка:
+// 1, 21, 31 пользователь; 2–4, 22–24 пользователя; 0, 5–20, 25–30 пользователей
+func pluralRussian(n int64) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralEnglish — правило для английского языка: 1 user, 0/2/5 users
+func pluralEnglish(n int64) string {
+	if n == 1 || n == -1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralOther — правило для языков без отдельных форм
+func pluralOther(int64) string {
+	return PluralOther
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPluralRussian(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, PluralMany},
+		{1, PluralOne},
+		{2, PluralFew},
+		{4, PluralFew},
+		{5, PluralMany},
+		{11, PluralMany},
+		{12, PluralMany},
+		{14, PluralMany},
+		{20, PluralMany},
+		{21, PluralOne},
+		{22, PluralFew},
+		{25, PluralMany},
+		{101, PluralOne},
+		{111, PluralMany},
+		{112, PluralMany},
+		{1004, PluralFew},
+		{-1, PluralOne},
+		{-3, PluralFew},
+		{-11, PluralMany},
+	}
+
+	for _, tt := range tests {
+		if got := pluralRussian(tt.n); got != tt.want {
+			t.Errorf("pluralRussian(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPluralEnglish(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, PluralOther},
+		{1, PluralOne},
+		{-1, PluralOne},
+		{2, PluralOther},
+		{11, PluralOther},
+		{21, PluralOther},
+	}
+
+	for _, tt := range tests {
+		if got := pluralEnglish(tt.n); got != tt.want {
+			t.Errorf("pluralEnglish(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTranslatorN(t *testing.T) {
+	dir := t.TempDir()
+	catalogs := map[string]string{
+		"ru.json": `{"users": {"one": "{count} пользователь", "few": "{count} пользователя", "many": "{count} пользователей", "other": "{count} пользователя"}}`,
+		"en.json": `{"users": {"one": "{count} user", "other": "{count} users"}}`,
+		"de.json": `{"users": {"other": "{count} Benutzer"}}`,
+	}
+	for name, data := range catalogs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bundle, err := LoadDir(dir, "ru")
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+
+	tests := []struct {
+		lang  string
+		count int64
+		want  string
+	}{
+		{"ru", 1, "1 пользователь"},
+		{"ru", 3, "3 пользователя"},
+		{"ru", 11, "11 пользователей"},
+		{"ru", 1234, "1\u00a0234 пользователя"},
+		{"ru", 1000000, "1\u00a0000\u00a0000 пользователей"},
+		{"en", 1, "1 user"},
+		{"en", 0, "0 users"},
+		{"en", 1234, "1,234 users"},
+		{"de", 1, "1 Benutzer"},
+		{"de", 7, "7 Benutzer"},
+	}
+
+	for _, tt := range tests {
+		if got := bundle.Translator(tt.lang).N("users", tt.count); got != tt.want {
+			t.Errorf("N(%s, %d) = %q, want %q", tt.lang, tt.count, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Watch проверяет файлы каталогов раз в interval и перечитывает их,
+// если файл изменён, добавлен или удалён. Блокирует вызывающего — запускайте в горутине
+func (b *Bundle) Watch(interval time.Duration) {
+	last := b.snapshot()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		current := b.snapshot()
+		if sameSnapshot(last, current) {
+			continue
+		}
+		last = current
+
+		if err := b.Reload(); err != nil {
+			log.Printf("Ошибка перезагрузки переводов: %v", err)
+			continue
+		}
+		log.Printf("Переводы перезагружены: %v", b.Languages())
+	}
+}
+
+// fileState — время изменения и размер файла каталога
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot собирает состояние файлов каталогов
+func (b *Bundle) snapshot() map[string]fileState {
+	paths, _ := filepath.Glob(filepath.Join(b.dir, "*.json"))
+
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return states
+}
+
+// sameSnapshot сравнивает два состояния файлов
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, exists := b[path]
+		if !exists || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+)
+
+// Ограничения Telegram для клавиатур
+const (
+	MaxInlineButtons     = 100 // Кнопок в инлайн-клавиатуре
+	MaxInlineRowButtons  = 8   // Кнопок в одном ряду инлайн-клавиатуры
+	MaxReplyRowButtons   = 12  // Кнопок в одном ряду обычной клавиатуры
+	MaxButtonTextLength  = 64  // Символов в подписи кнопки (длиннее клиенты обрезают)
+	MaxCallbackDataBytes = 64  // Байт в callback_data
+	MaxSwitchQueryLength = 256 // Символов в тексте switch_inline_query
+	MaxPlaceholderLength = 64  // Символов в подсказке поля ввода
+)
+
+// ErrEmptyKeyboard — в клавиатуре нет ни одной кнопки
+var ErrEmptyKeyboard = errors.New("клавиатура не содержит кнопок")
+
+// WebAppInfo описывает веб-приложение, которое открывает кнопка web_app.
+// В tgbotapi v5 такого поля нет, поэтому строители используют собственные типы кнопок
+type WebAppInfo struct {
+	URL string `json:"url"` // HTTPS-адрес веб-приложения
+}
+
+// layout раскладывает кнопки по рядам: сеткой из columns колонок
+// или с автопереносом, когда суммарная длина подписей превышает width
+type layout[B any] struct {
+	rows    [][]B // Готовые ряды
+	current []B   // Ряд, который сейчас заполняется
+	columns int   // Кнопок в ряду (0 — без ограничения)
+	width   int   // Максимальная суммарная длина подписей в ряду (0 — без ограничения)
+	used    int   // Суммарная длина подписей в текущем ряду
+	errs    []error
+}
+
+// add добавляет кнопку, начиная новый ряд, если текущий заполнен
+func (l *layout[B]) add(button B, text string) {
+	length := utf8.RuneCountInString(text)
+
+	full := l.columns > 0 && len(l.current) >= l.columns
+	wide := l.width > 0 && len(l.current) > 0 && l.used+length > l.width
+	if full || wide {
+		l.breakRow()
+	}
+
+	l.current = append(l.current, button)
+	l.used += length
+}
+
+// breakRow завершает текущий ряд
+func (l *layout[B]) breakRow() {
+	if len(l.current) > 0 {
+		l.rows = append(l.rows, l.current)
+	}
+	l.current = nil
+	l.used = 0
+}
+
+// fail запоминает ошибку валидации, чтобы вернуть её из Build
+func (l *layout[B]) fail(format string, args ...any) {
+	l.errs = append(l.errs, fmt.Errorf(format, args...))
+}
+
+// finish завершает раскладку и проверяет ограничения на количество кнопок
+func (l *layout[B]) finish(maxRow, maxTotal int) ([][]B, error) {
+	l.breakRow()
+
+	total := 0
+	for i, row := range l.rows {
+		if len(row) > maxRow {
+			l.fail("ряд %d: %d кнопок, допустимо не больше %d", i+1, len(row), maxRow)
+		}
+		total += len(row)
+	}
+
+	switch {
+	case total == 0:
+		l.errs = append(l.errs, ErrEmptyKeyboard)
+	case maxTotal > 0 && total > maxTotal:
+		l.fail("%d кнопок, допустимо не больше %d", total, maxTotal)
+	}
+
+	return l.rows, errors.Join(l.errs...)
+}
+
+// checkText проверяет подпись кнопки
+func (l *layout[B]) checkText(text string) {
+	length := utf8.RuneCountInString(text)
+	switch {
+	case length == 0:
+		l.fail("пустая подпись кнопки")
+	case length > MaxButtonTextLength:
+		l.fail("подпись %q длиннее %d символов", text, MaxButtonTextLength)
+	}
+}
+
+// checkURL проверяет адрес кнопки; httpsOnly — для web_app и login_url
+func (l *layout[B]) checkURL(text, rawURL string, httpsOnly bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" && parsed.Scheme != "tg" {
+		l.fail("кнопка %q: неверный адрес %q", text, rawURL)
+		return
+	}
+
+	if httpsOnly && parsed.Scheme != "https" {
+		l.fail("кнопка %q: адрес %q должен начинаться с https://", text, rawURL)
+		return
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "tg" {
+		l.fail("кнопка %q: неподдерживаемая схема адреса %q", text, parsed.Scheme)
+	}
+}
@@ -0,0 +1,312 @@
+package keyboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInlineBuilderLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(b *InlineBuilder)
+		wantErr string // Часть текста ошибки (пусто — ошибки быть не должно)
+	}{
+		{
+			name:  "одна кнопка",
+			build: func(b *InlineBuilder) { b.Callback("OK", "ok") },
+		},
+		{
+			name:    "пустая клавиатура",
+			build:   func(b *InlineBuilder) {},
+			wantErr: ErrEmptyKeyboard.Error(),
+		},
+		{
+			name:    "пустая подпись",
+			build:   func(b *InlineBuilder) { b.Callback("", "ok") },
+			wantErr: "пустая подпись",
+		},
+		{
+			name:  "подпись ровно 64 символа",
+			build: func(b *InlineBuilder) { b.Callback(strings.Repeat("я", MaxButtonTextLength), "ok") },
+		},
+		{
+			name:    "подпись длиннее 64 символов",
+			build:   func(b *InlineBuilder) { b.Callback(strings.Repeat("я", MaxButtonTextLength+1), "ok") },
+			wantErr: "длиннее 64 символов",
+		},
+		{
+			name:    "пустые callback-данные",
+			build:   func(b *InlineBuilder) { b.Callback("OK", "") },
+			wantErr: "пустые callback-данные",
+		},
+		{
+			name:  "callback-данные ровно 64 байта",
+			build: func(b *InlineBuilder) { b.Callback("OK", strings.Repeat("x", MaxCallbackDataBytes)) },
+		},
+		{
+			// Ограничение в байтах: 33 кириллические буквы — это 66 байт
+			name:    "callback-данные длиннее 64 байт",
+			build:   func(b *InlineBuilder) { b.Callback("OK", strings.Repeat("я", 33)) },
+			wantErr: "длиннее 64 байт",
+		},
+		{
+			name: "8 кнопок в ряду",
+			build: func(b *InlineBuilder) {
+				for range MaxInlineRowButtons {
+					b.Callback("b", "b")
+				}
+			},
+		},
+		{
+			name: "9 кнопок в ряду",
+			build: func(b *InlineBuilder) {
+				for range MaxInlineRowButtons + 1 {
+					b.Callback("b", "b")
+				}
+			},
+			wantErr: "ряд 1: 9 кнопок",
+		},
+		{
+			name: "100 кнопок",
+			build: func(b *InlineBuilder) {
+				b.Columns(5)
+				for range MaxInlineButtons {
+					b.Callback("b", "b")
+				}
+			},
+		},
+		{
+			name: "101 кнопка",
+			build: func(b *InlineBuilder) {
+				b.Columns(5)
+				for range MaxInlineButtons + 1 {
+					b.Callback("b", "b")
+				}
+			},
+			wantErr: "101 кнопок",
+		},
+		{
+			name:    "неверный адрес",
+			build:   func(b *InlineBuilder) { b.URL("Сайт", "example.com") },
+			wantErr: "неверный адрес",
+		},
+		{
+			name:    "неподдерживаемая схема",
+			build:   func(b *InlineBuilder) { b.URL("Сайт", "ftp://example.com") },
+			wantErr: "неподдерживаемая схема",
+		},
+		{
+			name:  "ссылка tg://",
+			build: func(b *InlineBuilder) { b.URL("Профиль", "tg://user?id=1") },
+		},
+		{
+			name:    "web_app по http",
+			build:   func(b *InlineBuilder) { b.WebApp("Приложение", "http://example.com") },
+			wantErr: "https://",
+		},
+		{
+			name: "длинный switch_inline_query",
+			build: func(b *InlineBuilder) {
+				b.SwitchInlineQuery("Поделиться", strings.Repeat("q", MaxSwitchQueryLength+1))
+			},
+			wantErr: "запрос длиннее",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewInlineBuilder()
+			tt.build(b)
+			_, err := b.Build()
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Build() error = %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("Build() error = nil, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("Build() error = %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInlineBuilderCollectsAllErrors(t *testing.T) {
+	_, err := NewInlineBuilder().
+		Callback("", "ok").
+		URL("Сайт", "ftp://example.com").
+		Build()
+	if err == nil {
+		t.Fatal("Build() error = nil")
+	}
+
+	for _, want := range []string{"пустая подпись", "неподдерживаемая схема"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestInlineBuilderLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b *InlineBuilder)
+		want  []int // Кнопок в каждом ряду
+	}{
+		{
+			name: "без ограничений — один ряд",
+			build: func(b *InlineBuilder) {
+				b.Callback("a", "a").Callback("b", "b").Callback("c", "c")
+			},
+			want: []int{3},
+		},
+		{
+			name: "сетка по 2",
+			build: func(b *InlineBuilder) {
+				b.Columns(2).Callback("a", "a").Callback("b", "b").Callback("c", "c")
+			},
+			want: []int{2, 1},
+		},
+		{
+			name: "явный перенос",
+			build: func(b *InlineBuilder) {
+				b.Callback("a", "a").Row().Callback("b", "b").Callback("c", "c")
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "пустой Row не создаёт ряд",
+			build: func(b *InlineBuilder) {
+				b.Row().Callback("a", "a").Row().Row().Callback("b", "b")
+			},
+			want: []int{1, 1},
+		},
+		{
+			name: "автоперенос по длине подписей",
+			build: func(b *InlineBuilder) {
+				b.Wrap(10).Callback("12345", "a").Callback("12345", "b").Callback("1", "c")
+			},
+			want: []int{2, 1},
+		},
+		{
+			name: "длинная подпись занимает ряд целиком",
+			build: func(b *InlineBuilder) {
+				b.Wrap(10).Callback("123456789012", "a").Callback("1", "b")
+			},
+			want: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewInlineBuilder()
+			tt.build(b)
+			kb, err := b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			got := make([]int, 0, len(kb.InlineKeyboard))
+			for _, row := range kb.InlineKeyboard {
+				got = append(got, len(row))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("rows = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("rows = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInlineKeyboardMarkup(t *testing.T) {
+	kb, err := NewInlineBuilder().Callback("OK", "ok").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	markup, err := kb.Markup()
+	if err != nil {
+		t.Fatalf("Markup() error = %v", err)
+	}
+	if data := markup.InlineKeyboard[0][0].CallbackData; data == nil || *data != "ok" {
+		t.Fatalf("callback data = %v, want ok", data)
+	}
+
+	kb, err = NewInlineBuilder().WebApp("Приложение", "https://example.com").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := kb.Markup(); !errors.Is(err, ErrWebAppNotSupported) {
+		t.Fatalf("Markup() error = %v, want %v", err, ErrWebAppNotSupported)
+	}
+}
+
+func TestReplyBuilderLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(b *ReplyBuilder)
+		wantErr string
+	}{
+		{
+			name:  "12 кнопок в ряду",
+			build: func(b *ReplyBuilder) { addReplyButtons(b, MaxReplyRowButtons) },
+		},
+		{
+			name:    "13 кнопок в ряду",
+			build:   func(b *ReplyBuilder) { addReplyButtons(b, MaxReplyRowButtons+1) },
+			wantErr: "ряд 1: 13 кнопок",
+		},
+		{
+			// Общего ограничения на количество кнопок у обычной клавиатуры нет
+			name:  "200 кнопок сеткой",
+			build: func(b *ReplyBuilder) { b.Columns(4); addReplyButtons(b, 200) },
+		},
+		{
+			name:    "длинная подсказка",
+			build:   func(b *ReplyBuilder) { b.Placeholder(strings.Repeat("п", MaxPlaceholderLength+1)).Text("OK") },
+			wantErr: "подсказка поля ввода",
+		},
+		{
+			name:    "неизвестный тип опроса",
+			build:   func(b *ReplyBuilder) { b.Poll("Опрос", "survey") },
+			wantErr: "неизвестный тип опроса",
+		},
+		{
+			name:  "викторина",
+			build: func(b *ReplyBuilder) { b.Poll("Викторина", PollQuiz) },
+		},
+		{
+			name:    "пустая клавиатура",
+			build:   func(b *ReplyBuilder) { b.Resize() },
+			wantErr: ErrEmptyKeyboard.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewReplyBuilder()
+			tt.build(b)
+			_, err := b.Build()
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Build() error = %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("Build() error = nil, want %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("Build() error = %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// addReplyButtons добавляет n текстовых кнопок
+func addReplyButtons(b *ReplyBuilder, n int) {
+	for range n {
+		b.Text("b")
+	}
+}
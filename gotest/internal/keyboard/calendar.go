@@ -0,0 +1,159 @@
+package keyboard
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Форматы дат в callback-данных календаря
+const (
+	CalendarMonthFormat = "2006-01"    // Месяц для навигации
+	CalendarDayFormat   = "2006-01-02" // Выбранный день
+)
+
+// Операции в callback-данных календаря и выбора времени:
+// "<prefix>:n:2026-10", "<prefix>:d:2026-10-18", "<prefix>:t:1430", "<prefix>:ok:1430"
+const (
+	CalendarOpNavigate = "n"  // Показать другой месяц
+	CalendarOpDay      = "d"  // Выбран день
+	CalendarOpTime     = "t"  // Изменено время
+	CalendarOpDone     = "ok" // Время подтверждено
+	CalendarOpNoop     = "x"  // Кнопка без действия (заголовок, пустая клетка)
+)
+
+// CalendarNames — названия месяцев и дней недели на языке пользователя.
+// Пустое название месяца заменяется его номером
+type CalendarNames struct {
+	Months   [12]string   // Январь..Декабрь
+	Weekdays [7]string    // Начиная с воскресенья, как time.Weekday
+	FirstDay time.Weekday // С какого дня начинается неделя
+}
+
+// CalendarOptions — ограничения и язык календаря
+type CalendarOptions struct {
+	Min   time.Time     // Самая ранняя доступная дата (нулевое значение — без ограничения)
+	Max   time.Time     // Самая поздняя доступная дата (нулевое значение — без ограничения)
+	Names CalendarNames // Названия месяцев и дней недели
+}
+
+// Allows сообщает, можно ли выбрать день (сравниваются только даты)
+func (o CalendarOptions) Allows(day time.Time) bool {
+	date := truncateDay(day)
+	if !o.Min.IsZero() && date.Before(truncateDay(o.Min.In(day.Location()))) {
+		return false
+	}
+	if !o.Max.IsZero() && date.After(truncateDay(o.Max.In(day.Location()))) {
+		return false
+	}
+	return true
+}
+
+// NewCalendarKeyboard создаёт клавиатуру-календарь на месяц, в котором находится month
+func NewCalendarKeyboard(dataPrefix string, month time.Time, opts CalendarOptions) tgbotapi.InlineKeyboardMarkup {
+	names := opts.Names
+
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	last := first.AddDate(0, 1, -1)
+	noop := tgbotapi.NewInlineKeyboardButtonData(" ", dataPrefix+":"+CalendarOpNoop)
+
+	// Заголовок: ‹ Октябрь 2026 ›
+	header := make([]tgbotapi.InlineKeyboardButton, 0, 3)
+	if prev := first.AddDate(0, -1, 0); opts.Min.IsZero() || !first.AddDate(0, 0, -1).Before(truncateDay(opts.Min.In(first.Location()))) {
+		header = append(header, calendarButton("‹", dataPrefix, CalendarOpNavigate, prev.Format(CalendarMonthFormat)))
+	} else {
+		header = append(header, noop)
+	}
+	title := fmt.Sprintf("%s %d", names.Months[first.Month()-1], first.Year())
+	if names.Months[first.Month()-1] == "" {
+		title = first.Format("01.2006")
+	}
+	header = append(header, tgbotapi.NewInlineKeyboardButtonData(title, dataPrefix+":"+CalendarOpNoop))
+	if next := first.AddDate(0, 1, 0); opts.Max.IsZero() || !next.After(opts.Max) {
+		header = append(header, calendarButton("›", dataPrefix, CalendarOpNavigate, next.Format(CalendarMonthFormat)))
+	} else {
+		header = append(header, noop)
+	}
+
+	// Дни недели в порядке, принятом для языка
+	weekdays := make([]tgbotapi.InlineKeyboardButton, 0, 7)
+	for i := range 7 {
+		weekday := (names.FirstDay + time.Weekday(i)) % 7
+		weekdays = append(weekdays, tgbotapi.NewInlineKeyboardButtonData(cmp.Or(names.Weekdays[weekday], " "), dataPrefix+":"+CalendarOpNoop))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{header, weekdays}
+
+	// Пустые клетки до первого числа месяца
+	week := make([]tgbotapi.InlineKeyboardButton, 0, 7)
+	for offset := (first.Weekday() - names.FirstDay + 7) % 7; offset > 0; offset-- {
+		week = append(week, noop)
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if opts.Allows(day) {
+			week = append(week, calendarButton(fmt.Sprint(day.Day()), dataPrefix, CalendarOpDay, day.Format(CalendarDayFormat)))
+		} else {
+			week = append(week, tgbotapi.NewInlineKeyboardButtonData("·", dataPrefix+":"+CalendarOpNoop))
+		}
+
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]tgbotapi.InlineKeyboardButton, 0, 7)
+		}
+	}
+
+	// Пустые клетки после последнего числа
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, noop)
+		}
+		rows = append(rows, week)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// NewTimePickerKeyboard создаёт клавиатуру выбора времени со стрелками для часов и минут.
+// Каждая стрелка содержит в данных итоговое время, поэтому состояние хранить не нужно
+func NewTimePickerKeyboard(dataPrefix string, hour, minute, minuteStep int) tgbotapi.InlineKeyboardMarkup {
+	if minuteStep <= 0 {
+		minuteStep = 1
+	}
+
+	at := func(h, m int) string {
+		h = (h%24 + 24) % 24
+		m = (m%60 + 60) % 60
+		return fmt.Sprintf("%02d%02d", h, m)
+	}
+
+	up := tgbotapi.NewInlineKeyboardRow(
+		calendarButton("▲", dataPrefix, CalendarOpTime, at(hour+1, minute)),
+		calendarButton("▲", dataPrefix, CalendarOpTime, at(hour, minute+minuteStep)),
+	)
+	value := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%02d", hour), dataPrefix+":"+CalendarOpNoop),
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%02d", minute), dataPrefix+":"+CalendarOpNoop),
+	)
+	down := tgbotapi.NewInlineKeyboardRow(
+		calendarButton("▼", dataPrefix, CalendarOpTime, at(hour-1, minute)),
+		calendarButton("▼", dataPrefix, CalendarOpTime, at(hour, minute-minuteStep)),
+	)
+	done := tgbotapi.NewInlineKeyboardRow(
+		calendarButton(fmt.Sprintf("✅ %02d:%02d", hour, minute), dataPrefix, CalendarOpDone, at(hour, minute)),
+	)
+
+	return tgbotapi.NewInlineKeyboardMarkup(up, value, down, done)
+}
+
+// calendarButton создаёт кнопку с данными "<prefix>:<операция>:<значение>"
+func calendarButton(text, dataPrefix, op, value string) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(text, dataPrefix+":"+op+":"+value)
+}
+
+// truncateDay отбрасывает время, оставляя начало дня в том же часовом поясе
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
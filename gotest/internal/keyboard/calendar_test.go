@@ -0,0 +1,191 @@
+package keyboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestCalendarOptionsAllows(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("нет базы часовых поясов: %v", err)
+	}
+
+	opts := CalendarOptions{
+		Min: time.Date(2026, 10, 10, 15, 30, 0, 0, moscow),
+		Max: time.Date(2026, 10, 20, 9, 0, 0, 0, moscow),
+	}
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"до Min", time.Date(2026, 10, 9, 0, 0, 0, 0, moscow), false},
+		{"день Min раньше его времени", time.Date(2026, 10, 10, 0, 0, 0, 0, moscow), true},
+		{"между границами", time.Date(2026, 10, 15, 0, 0, 0, 0, moscow), true},
+		{"день Max позже его времени", time.Date(2026, 10, 20, 23, 0, 0, 0, moscow), true},
+		{"после Max", time.Date(2026, 10, 21, 0, 0, 0, 0, moscow), false},
+	}
+
+	for _, tt := range tests {
+		if got := opts.Allows(tt.day); got != tt.want {
+			t.Errorf("%s: Allows(%v) = %v, want %v", tt.name, tt.day, got, tt.want)
+		}
+	}
+
+	// Даты сравниваются в поясе дня: 10.10 01:00 в Москве — это 09.10 по UTC
+	early := CalendarOptions{Min: time.Date(2026, 10, 10, 1, 0, 0, 0, moscow)}
+	if early.Allows(time.Date(2026, 10, 9, 12, 0, 0, 0, moscow)) {
+		t.Error("09.10 в Москве раньше Min")
+	}
+	if !early.Allows(time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC)) {
+		t.Error("09.10 по UTC — день Min")
+	}
+
+	if !(CalendarOptions{}).Allows(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("календарь без ограничений не должен запрещать дни")
+	}
+}
+
+func TestNewCalendarKeyboardLayout(t *testing.T) {
+	names := CalendarNames{
+		Months:   [12]string{"Январь", "Февраль", "Март", "Апрель", "Май", "Июнь", "Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+		Weekdays: [7]string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+	}
+
+	tests := []struct {
+		name         string
+		month        time.Time
+		firstDay     time.Weekday
+		wantTitle    string
+		wantWeekdays string
+		wantOffset   int // Пустых клеток перед первым числом
+		wantWeeks    int
+	}{
+		// 1 октября 2026 — четверг
+		{"неделя с понедельника", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), time.Monday, "Октябрь 2026", "Пн Вт Ср Чт Пт Сб Вс", 3, 5},
+		{"неделя с воскресенья", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), time.Sunday, "Октябрь 2026", "Вс Пн Вт Ср Чт Пт Сб", 4, 5},
+		// 1 февраля 2026 — воскресенье: 28 дней с понедельника занимают 5 недель
+		{"февраль с воскресенья", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Monday, "Февраль 2026", "Пн Вт Ср Чт Пт Сб Вс", 6, 5},
+		{"февраль ровно в 4 недели", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Sunday, "Февраль 2026", "Вс Пн Вт Ср Чт Пт Сб", 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := CalendarOptions{Names: names}
+			opts.Names.FirstDay = tt.firstDay
+			markup := NewCalendarKeyboard("cal", tt.month, opts)
+			rows := markup.InlineKeyboard
+
+			if got := rows[0][1].Text; got != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got, tt.wantTitle)
+			}
+			if got := rowLabels(rows[1]); got != tt.wantWeekdays {
+				t.Errorf("weekdays = %q, want %q", got, tt.wantWeekdays)
+			}
+			if got := len(rows) - 2; got != tt.wantWeeks {
+				t.Errorf("weeks = %d, want %d", got, tt.wantWeeks)
+			}
+			for i, row := range rows[2:] {
+				if len(row) != 7 {
+					t.Errorf("week %d has %d buttons", i+1, len(row))
+				}
+			}
+
+			offset := 0
+			for _, button := range rows[2] {
+				if button.Text != " " {
+					break
+				}
+				offset++
+			}
+			if offset != tt.wantOffset {
+				t.Errorf("offset = %d, want %d", offset, tt.wantOffset)
+			}
+			if got := *rows[2][offset].CallbackData; !strings.HasSuffix(got, ":"+CalendarOpDay+":"+tt.month.Format("2006-01")+"-01") {
+				t.Errorf("first day data = %q", got)
+			}
+		})
+	}
+}
+
+func TestNewCalendarKeyboardLimits(t *testing.T) {
+	opts := CalendarOptions{
+		Min: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Max: time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC),
+	}
+	markup := NewCalendarKeyboard("cal", opts.Min, opts)
+	rows := markup.InlineKeyboard
+
+	// Без названий месяц подписывается номером
+	if got := rows[0][1].Text; got != "10.2026" {
+		t.Errorf("title = %q, want 10.2026", got)
+	}
+
+	// Оба соседних месяца недоступны — стрелок нет
+	for _, i := range []int{0, 2} {
+		if data := *rows[0][i].CallbackData; data != "cal:"+CalendarOpNoop {
+			t.Errorf("header button %d data = %q, want no-op", i, data)
+		}
+	}
+
+	var days []string
+	for _, week := range rows[2:] {
+		for _, button := range week {
+			if data := *button.CallbackData; strings.Contains(data, ":"+CalendarOpDay+":") {
+				days = append(days, button.Text)
+			}
+		}
+	}
+	if got := strings.Join(days, " "); got != "18 19 20 21 22 23 24 25" {
+		t.Errorf("available days = %q", got)
+	}
+}
+
+func TestNewTimePickerKeyboard(t *testing.T) {
+	tests := []struct {
+		name                 string
+		hour, minute, step   int
+		hourUp, hourDown     string
+		minuteUp, minuteDown string
+		done                 string
+	}{
+		// Часы и минуты листаются независимо: минуты не переносятся в часы
+		{"середина дня", 14, 30, 15, "1530", "1330", "1445", "1415", "1430"},
+		{"переход через полночь", 23, 45, 15, "0045", "2245", "2300", "2330", "2345"},
+		{"начало суток", 0, 0, 5, "0100", "2300", "0005", "0055", "0000"},
+		{"шаг по умолчанию", 9, 59, 0, "1059", "0859", "0900", "0958", "0959"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := NewTimePickerKeyboard("tp", tt.hour, tt.minute, tt.step).InlineKeyboard
+
+			want := map[*tgbotapi.InlineKeyboardButton]string{
+				&rows[0][0]: "tp:t:" + tt.hourUp,
+				&rows[0][1]: "tp:t:" + tt.minuteUp,
+				&rows[2][0]: "tp:t:" + tt.hourDown,
+				&rows[2][1]: "tp:t:" + tt.minuteDown,
+				&rows[3][0]: "tp:ok:" + tt.done,
+			}
+			for button, data := range want {
+				if got := *button.CallbackData; got != data {
+					t.Errorf("%s: data = %q, want %q", button.Text, got, data)
+				}
+			}
+		})
+	}
+}
+
+// rowLabels возвращает подписи кнопок ряда через пробел
+func rowLabels(row []tgbotapi.InlineKeyboardButton) string {
+	labels := make([]string, 0, len(row))
+	for _, button := range row {
+		labels = append(labels, button.Text)
+	}
+	return strings.Join(labels, " ")
+}
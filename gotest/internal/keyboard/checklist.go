@@ -0,0 +1,38 @@
+package keyboard
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Операции в callback-данных списка: "<prefix>:t:<ключ>" и "<prefix>:done"
+const (
+	ChecklistOpToggle = "t"    // Отметить или снять отметку с варианта
+	ChecklistOpDone   = "done" // Завершить выбор
+)
+
+// ChecklistOption — вариант в списке с множественным выбором
+type ChecklistOption struct {
+	Key   string // Короткий идентификатор варианта (попадает в callback-данные)
+	Label string // Подпись кнопки
+}
+
+// NewChecklistKeyboard создаёт клавиатуру, где каждый вариант — кнопка с отметкой ✅,
+// а последняя кнопка завершает выбор
+func NewChecklistKeyboard(dataPrefix string, options []ChecklistOption, selected map[string]bool, doneLabel string) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(options)+1)
+
+	for _, option := range options {
+		mark := "▫️ "
+		if selected[option.Key] {
+			mark = "✅ "
+		}
+
+		button := tgbotapi.NewInlineKeyboardButtonData(mark+option.Label, dataPrefix+":"+ChecklistOpToggle+":"+option.Key)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+	}
+
+	done := tgbotapi.NewInlineKeyboardButtonData(doneLabel, dataPrefix+":"+ChecklistOpDone)
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(done))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,150 @@
+package keyboard
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// InlineButton — инлайн-кнопка. Дополняет кнопку tgbotapi полем web_app
+type InlineButton struct {
+	tgbotapi.InlineKeyboardButton
+	WebApp *WebAppInfo `json:"web_app,omitempty"` // Открыть веб-приложение
+}
+
+// InlineKeyboard — инлайн-клавиатура, собранная InlineBuilder.
+// Подходит как ReplyMarkup для tgbotapi.MessageConfig и других Send-конфигураций
+type InlineKeyboard struct {
+	InlineKeyboard [][]InlineButton `json:"inline_keyboard"`
+}
+
+// ErrWebAppNotSupported — клавиатуру с web_app нельзя передать туда,
+// где tgbotapi ожидает tgbotapi.InlineKeyboardMarkup (например, при редактировании)
+var ErrWebAppNotSupported = errors.New("кнопки web_app не поддерживаются в tgbotapi.InlineKeyboardMarkup")
+
+// Markup преобразует клавиатуру в тип tgbotapi
+func (k InlineKeyboard) Markup() (tgbotapi.InlineKeyboardMarkup, error) {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(k.InlineKeyboard))
+	for _, buttons := range k.InlineKeyboard {
+		row := make([]tgbotapi.InlineKeyboardButton, 0, len(buttons))
+		for _, button := range buttons {
+			if button.WebApp != nil {
+				return tgbotapi.InlineKeyboardMarkup{}, ErrWebAppNotSupported
+			}
+			row = append(row, button.InlineKeyboardButton)
+		}
+		rows = append(rows, row)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// InlineBuilder собирает инлайн-клавиатуру цепочкой вызовов:
+//
+//	kb, err := keyboard.NewInlineBuilder().Columns(2).
+//		Callback("✅ Да", "confirm_yes").
+//		Callback("❌ Нет", "confirm_no").
+//		Row().
+//		URL("Сайт", "https://example.com").
+//		Build()
+type InlineBuilder struct {
+	layout layout[InlineButton]
+}
+
+// NewInlineBuilder создаёт строитель инлайн-клавиатуры
+func NewInlineBuilder() *InlineBuilder {
+	return &InlineBuilder{}
+}
+
+// Columns раскладывает следующие кнопки сеткой по n в ряд
+func (b *InlineBuilder) Columns(n int) *InlineBuilder {
+	b.layout.columns = n
+	return b
+}
+
+// Wrap переносит кнопки на новый ряд, когда суммарная длина подписей превышает width
+func (b *InlineBuilder) Wrap(width int) *InlineBuilder {
+	b.layout.width = width
+	return b
+}
+
+// Row завершает текущий ряд: следующая кнопка начнёт новый
+func (b *InlineBuilder) Row() *InlineBuilder {
+	b.layout.breakRow()
+	return b
+}
+
+// Callback добавляет кнопку, которая присылает боту callback-запрос с data
+func (b *InlineBuilder) Callback(text, data string) *InlineBuilder {
+	switch size := len(data); {
+	case size == 0:
+		b.layout.fail("кнопка %q: пустые callback-данные", text)
+	case size > MaxCallbackDataBytes:
+		b.layout.fail("кнопка %q: callback-данные длиннее %d байт", text, MaxCallbackDataBytes)
+	}
+
+	return b.add(tgbotapi.NewInlineKeyboardButtonData(text, data))
+}
+
+// URL добавляет кнопку-ссылку (http, https или tg)
+func (b *InlineBuilder) URL(text, rawURL string) *InlineBuilder {
+	b.layout.checkURL(text, rawURL, false)
+	return b.add(tgbotapi.NewInlineKeyboardButtonURL(text, rawURL))
+}
+
+// SwitchInlineQuery добавляет кнопку, которая предлагает выбрать чат
+// и вставляет в поле ввода "@бот query"
+func (b *InlineBuilder) SwitchInlineQuery(text, query string) *InlineBuilder {
+	b.checkQuery(text, query)
+	return b.add(tgbotapi.NewInlineKeyboardButtonSwitch(text, query))
+}
+
+// SwitchInlineQueryCurrentChat работает как SwitchInlineQuery, но в текущем чате
+func (b *InlineBuilder) SwitchInlineQueryCurrentChat(text, query string) *InlineBuilder {
+	b.checkQuery(text, query)
+	return b.add(tgbotapi.InlineKeyboardButton{
+		Text:                         text,
+		SwitchInlineQueryCurrentChat: &query,
+	})
+}
+
+// LoginURL добавляет кнопку авторизации через Telegram Login
+func (b *InlineBuilder) LoginURL(text string, login tgbotapi.LoginURL) *InlineBuilder {
+	b.layout.checkURL(text, login.URL, true)
+	return b.add(tgbotapi.NewInlineKeyboardButtonLoginURL(text, login))
+}
+
+// WebApp добавляет кнопку, открывающую веб-приложение
+func (b *InlineBuilder) WebApp(text, rawURL string) *InlineBuilder {
+	b.layout.checkURL(text, rawURL, true)
+	b.layout.checkText(text)
+	b.layout.add(InlineButton{
+		InlineKeyboardButton: tgbotapi.InlineKeyboardButton{Text: text},
+		WebApp:               &WebAppInfo{URL: rawURL},
+	}, text)
+	return b
+}
+
+// Build возвращает клавиатуру или все найденные нарушения ограничений Telegram
+func (b *InlineBuilder) Build() (InlineKeyboard, error) {
+	rows, err := b.layout.finish(MaxInlineRowButtons, MaxInlineButtons)
+	if err != nil {
+		return InlineKeyboard{}, err
+	}
+	return InlineKeyboard{InlineKeyboard: rows}, nil
+}
+
+// add проверяет подпись и добавляет кнопку в раскладку
+func (b *InlineBuilder) add(button tgbotapi.InlineKeyboardButton) *InlineBuilder {
+	b.layout.checkText(button.Text)
+	b.layout.add(InlineButton{InlineKeyboardButton: button}, button.Text)
+	return b
+}
+
+// checkQuery проверяет текст для switch_inline_query
+func (b *InlineBuilder) checkQuery(text, query string) {
+	if utf8.RuneCountInString(query) > MaxSwitchQueryLength {
+		b.layout.fail("кнопка %q: запрос длиннее %d символов", text, MaxSwitchQueryLength)
+	}
+}
@@ -4,9 +4,14 @@ import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 // NewConfirmKeyboard создаёт клавиатуру с кнопками "Да" и "Нет"
 func NewConfirmKeyboard(dataPrefix string) tgbotapi.InlineKeyboardMarkup {
+	return NewConfirmKeyboardWithLabels(dataPrefix, "✅ Да", "❌ Нет")
+}
+
+// NewConfirmKeyboardWithLabels создаёт клавиатуру подтверждения с переведёнными подписями кнопок
+func NewConfirmKeyboardWithLabels(dataPrefix, yes, no string) tgbotapi.InlineKeyboardMarkup {
 	// Создаём инлайн-кнопки
-	btnYes := tgbotapi.NewInlineKeyboardButtonData("✅ Да", dataPrefix+"_yes")
-	btnNo := tgbotapi.NewInlineKeyboardButtonData("❌ Нет", dataPrefix+"_no")
+	btnYes := tgbotapi.NewInlineKeyboardButtonData(yes, dataPrefix+"_yes")
+	btnNo := tgbotapi.NewInlineKeyboardButtonData(no, dataPrefix+"_no")
 
 	// Создаём ряд кнопок
 	row := tgbotapi.NewInlineKeyboardRow(btnYes, btnNo)
@@ -16,3 +21,13 @@ func NewConfirmKeyboard(dataPrefix string) tgbotapi.InlineKeyboardMarkup {
 
 	return keyboard
 }
+
+// NewLanguageInlineKeyboard создаёт инлайн-клавиатуру выбора языка
+func NewLanguageInlineKeyboard() tgbotapi.InlineKeyboardMarkup {
+	btnRussian := tgbotapi.NewInlineKeyboardButtonData("🇷🇺 Русский", "lang_ru")
+	btnEnglish := tgbotapi.NewInlineKeyboardButtonData("🇬🇧 English", "lang_en")
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(btnRussian, btnEnglish),
+	)
+}
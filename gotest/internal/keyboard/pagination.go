@@ -0,0 +1,49 @@
+package keyboard
+
+import (
+	"fmt"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// paginationWindow — сколько номеров страниц показывать в ряду навигации
+const paginationWindow = 5
+
+// NewPaginationRow создаёт ряд кнопок навигации по страницам: "‹ 1 ·2· 3 4 5 ›"
+// Страницы нумеруются с 1, данные кнопок имеют вид "<dataPrefix>:<страница>"
+func NewPaginationRow(dataPrefix string, page, pages int) []tgbotapi.InlineKeyboardButton {
+	if pages <= 1 {
+		return nil
+	}
+
+	pageButton := func(text string, target int) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(text, dataPrefix+":"+strconv.Itoa(target))
+	}
+
+	// Окно номеров вокруг текущей страницы
+	first := max(1, page-paginationWindow/2)
+	last := min(pages, first+paginationWindow-1)
+	first = max(1, last-paginationWindow+1)
+
+	var row []tgbotapi.InlineKeyboardButton
+
+	if page > 1 {
+		row = append(row, pageButton("‹", page-1))
+	}
+
+	for number := first; number <= last; number++ {
+		text := strconv.Itoa(number)
+		if number == page {
+			// Текущая страница выделена, нажатие на неё ничего не меняет
+			text = fmt.Sprintf("·%d·", number)
+		}
+		row = append(row, pageButton(text, number))
+	}
+
+	if page < pages {
+		row = append(row, pageButton("›", page+1))
+	}
+
+	return row
+}
@@ -0,0 +1,54 @@
+package keyboard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewPaginationRow(t *testing.T) {
+	tests := []struct {
+		name  string
+		page  int
+		pages int
+		want  string // Подписи кнопок через пробел
+	}{
+		{"одна страница", 1, 1, ""},
+		{"нет страниц", 1, 0, ""},
+		{"первая из двух", 1, 2, "·1· 2 ›"},
+		{"последняя из двух", 2, 2, "‹ 1 ·2·"},
+		{"середина короткого списка", 2, 3, "‹ 1 ·2· 3 ›"},
+		{"начало длинного списка", 1, 10, "·1· 2 3 4 5 ›"},
+		{"окно у начала", 2, 10, "‹ 1 ·2· 3 4 5 ›"},
+		{"окно по центру", 5, 10, "‹ 3 4 ·5· 6 7 ›"},
+		{"окно у конца", 9, 10, "‹ 6 7 8 ·9· 10 ›"},
+		{"конец длинного списка", 10, 10, "‹ 6 7 8 9 ·10·"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := NewPaginationRow("p", tt.page, tt.pages)
+
+			labels := make([]string, 0, len(row))
+			for _, button := range row {
+				labels = append(labels, button.Text)
+			}
+			if got := strings.Join(labels, " "); got != tt.want {
+				t.Fatalf("NewPaginationRow(%d, %d) = %q, want %q", tt.page, tt.pages, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPaginationRowData(t *testing.T) {
+	row := NewPaginationRow("chats", 5, 10)
+
+	want := []string{"chats:4", "chats:3", "chats:4", "chats:5", "chats:6", "chats:7", "chats:6"}
+	if len(row) != len(want) {
+		t.Fatalf("len(row) = %d, want %d", len(row), len(want))
+	}
+	for i, button := range row {
+		if button.CallbackData == nil || *button.CallbackData != want[i] {
+			t.Errorf("button %q data = %v, want %q", button.Text, button.CallbackData, want[i])
+		}
+	}
+}
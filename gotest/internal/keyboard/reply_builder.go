@@ -0,0 +1,146 @@
+package keyboard
+
+import (
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Типы опросов для кнопки request_poll
+const (
+	PollAny     = ""        // Пользователь выбирает тип сам
+	PollRegular = "regular" // Обычный опрос
+	PollQuiz    = "quiz"    // Викторина
+)
+
+// ReplyButton — кнопка обычной клавиатуры. Дополняет кнопку tgbotapi полем web_app
+type ReplyButton struct {
+	tgbotapi.KeyboardButton
+	WebApp *WebAppInfo `json:"web_app,omitempty"` // Открыть веб-приложение
+}
+
+// ReplyKeyboard — обычная клавиатура, собранная ReplyBuilder.
+// Подходит как ReplyMarkup для tgbotapi.MessageConfig
+type ReplyKeyboard struct {
+	Keyboard              [][]ReplyButton `json:"keyboard"`
+	ResizeKeyboard        bool            `json:"resize_keyboard,omitempty"`
+	OneTimeKeyboard       bool            `json:"one_time_keyboard,omitempty"`
+	InputFieldPlaceholder string          `json:"input_field_placeholder,omitempty"`
+	Selective             bool            `json:"selective,omitempty"`
+}
+
+// ReplyBuilder собирает обычную клавиатуру цепочкой вызовов:
+//
+//	kb, err := keyboard.NewReplyBuilder().Resize().
+//		Contact("📱 Отправить телефон").
+//		Location("📍 Отправить геолокацию").
+//		Build()
+type ReplyBuilder struct {
+	layout   layout[ReplyButton]
+	keyboard ReplyKeyboard
+}
+
+// NewReplyBuilder создаёт строитель обычной клавиатуры
+func NewReplyBuilder() *ReplyBuilder {
+	return &ReplyBuilder{}
+}
+
+// Columns раскладывает следующие кнопки сеткой по n в ряд
+func (b *ReplyBuilder) Columns(n int) *ReplyBuilder {
+	b.layout.columns = n
+	return b
+}
+
+// Wrap переносит кнопки на новый ряд, когда суммарная длина подписей превышает width
+func (b *ReplyBuilder) Wrap(width int) *ReplyBuilder {
+	b.layout.width = width
+	return b
+}
+
+// Row завершает текущий ряд: следующая кнопка начнёт новый
+func (b *ReplyBuilder) Row() *ReplyBuilder {
+	b.layout.breakRow()
+	return b
+}
+
+// Resize просит клиент подогнать высоту клавиатуры под кнопки
+func (b *ReplyBuilder) Resize() *ReplyBuilder {
+	b.keyboard.ResizeKeyboard = true
+	return b
+}
+
+// OneTime скрывает клавиатуру после первого нажатия
+func (b *ReplyBuilder) OneTime() *ReplyBuilder {
+	b.keyboard.OneTimeKeyboard = true
+	return b
+}
+
+// Selective показывает клавиатуру только упомянутым пользователям
+func (b *ReplyBuilder) Selective() *ReplyBuilder {
+	b.keyboard.Selective = true
+	return b
+}
+
+// Placeholder задаёт подсказку в поле ввода, пока клавиатура открыта
+func (b *ReplyBuilder) Placeholder(text string) *ReplyBuilder {
+	if utf8.RuneCountInString(text) > MaxPlaceholderLength {
+		b.layout.fail("подсказка поля ввода длиннее %d символов", MaxPlaceholderLength)
+	}
+	b.keyboard.InputFieldPlaceholder = text
+	return b
+}
+
+// Text добавляет кнопку, которая отправляет свою подпись как сообщение
+func (b *ReplyBuilder) Text(text string) *ReplyBuilder {
+	return b.add(ReplyButton{KeyboardButton: tgbotapi.NewKeyboardButton(text)})
+}
+
+// Contact добавляет кнопку отправки номера телефона (только в личных чатах)
+func (b *ReplyBuilder) Contact(text string) *ReplyBuilder {
+	return b.add(ReplyButton{KeyboardButton: tgbotapi.NewKeyboardButtonContact(text)})
+}
+
+// Location добавляет кнопку отправки геолокации (только в личных чатах)
+func (b *ReplyBuilder) Location(text string) *ReplyBuilder {
+	return b.add(ReplyButton{KeyboardButton: tgbotapi.NewKeyboardButtonLocation(text)})
+}
+
+// Poll добавляет кнопку создания опроса; pollType — PollAny, PollRegular или PollQuiz
+func (b *ReplyBuilder) Poll(text, pollType string) *ReplyBuilder {
+	if pollType != PollAny && pollType != PollRegular && pollType != PollQuiz {
+		b.layout.fail("кнопка %q: неизвестный тип опроса %q", text, pollType)
+	}
+
+	return b.add(ReplyButton{KeyboardButton: tgbotapi.KeyboardButton{
+		Text:        text,
+		RequestPoll: &tgbotapi.KeyboardButtonPollType{Type: pollType},
+	}})
+}
+
+// WebApp добавляет кнопку, открывающую веб-приложение
+func (b *ReplyBuilder) WebApp(text, rawURL string) *ReplyBuilder {
+	b.layout.checkURL(text, rawURL, true)
+	return b.add(ReplyButton{
+		KeyboardButton: tgbotapi.NewKeyboardButton(text),
+		WebApp:         &WebAppInfo{URL: rawURL},
+	})
+}
+
+// Build возвращает клавиатуру или все найденные нарушения ограничений Telegram
+func (b *ReplyBuilder) Build() (ReplyKeyboard, error) {
+	rows, err := b.layout.finish(MaxReplyRowButtons, 0)
+	if err != nil {
+		return ReplyKeyboard{}, err
+	}
+
+	keyboard := b.keyboard
+	keyboard.Keyboard = rows
+	return keyboard, nil
+}
+
+// add проверяет подпись и добавляет кнопку в раскладку
+func (b *ReplyBuilder) add(button ReplyButton) *ReplyBuilder {
+	b.layout.checkText(button.Text)
+	b.layout.add(button, button.Text)
+	return b
+}
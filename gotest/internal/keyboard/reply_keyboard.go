@@ -16,25 +16,3 @@ func NewLanguageKeyboard() tgbotapi.ReplyKeyboardMarkup {
 
 	return keyboard
 }
-
-// NewMainMenuKeyboard создаёт главное меню бота
-func NewMainMenuKeyboard() tgbotapi.ReplyKeyboardMarkup {
-    // Первый ряд
-    btnProfile := tgbotapi.NewKeyboardButton("👤 Профиль")
-    btnSettings := tgbotapi.NewKeyboardButton("⚙️ Настройки")
-    row1 := tgbotapi.NewKeyboardButtonRow(btnProfile, btnSettings)
-    
-    // Второй ряд
-    btnHelp := tgbotapi.NewKeyboardButton("❓ Помощь")
-    btnAbout := tgbotapi.NewKeyboardButton("ℹ️ О боте")
-    row2 := tgbotapi.NewKeyboardButtonRow(btnHelp, btnAbout)
-    
-    // Третий ряд (одна кнопка на весь ряд)
-    btnCancel := tgbotapi.NewKeyboardButton("❌ Отмена")
-    row3 := tgbotapi.NewKeyboardButtonRow(btnCancel)
-    
-    // Создаём клавиатуру из всех рядов
-    keyboard := tgbotapi.NewReplyKeyboard(row1, row2, row3)
-    
-    return keyboard
-}
\ No newline at end of file
@@ -0,0 +1,208 @@
+package menu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Роли пользователей, от которых зависит видимость пунктов меню
+const (
+	RoleUser  = "user"  // Любой пользователь
+	RoleAdmin = "admin" // Администратор бота
+)
+
+// maxIDLength — ограничение на длину ID меню и названий действий,
+// чтобы callback-данные кнопок уложились в 64 байта
+const maxIDLength = 48
+
+// Menu — экран меню: заголовок, текст и кнопки
+type Menu struct {
+	ID      string `json:"id" yaml:"id"`           // Уникальный идентификатор экрана
+	Title   string `json:"title" yaml:"title"`     // Заголовок (выводится жирным)
+	Text    string `json:"text" yaml:"text"`       // Текст под заголовком
+	Columns int    `json:"columns" yaml:"columns"` // Кнопок в ряду (0 — по одной)
+	Items   []Item `json:"items" yaml:"items"`     // Пункты меню
+}
+
+// Item — пункт меню. Ровно одно из полей Menu, Action или URL должно быть заполнено
+type Item struct {
+	Title  string   `json:"title" yaml:"title"`   // Подпись кнопки
+	Menu   *Menu    `json:"menu" yaml:"menu"`     // Вложенное меню
+	Action string   `json:"action" yaml:"action"` // Имя обработчика, который запускает кнопка
+	URL    string   `json:"url" yaml:"url"`       // Внешняя ссылка
+	Roles  []string `json:"roles" yaml:"roles"`   // Кому виден пункт (пусто — всем)
+}
+
+// VisibleTo сообщает, виден ли пункт пользователю с указанными ролями
+func (i Item) VisibleTo(roles []string) bool {
+	if len(i.Roles) == 0 {
+		return true
+	}
+
+	for _, role := range i.Roles {
+		if slices.Contains(roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tree — загруженное дерево меню с быстрым поиском экранов и действий.
+// Для каждого экрана и действия хранится путь от корня: пункт виден,
+// только если видны он сам и все пункты, через которые к нему попадают
+type Tree struct {
+	Root    *Menu               // Корневое меню
+	menus   map[string]*Menu    // Карта: ID -> экран
+	paths   map[string][]Item   // Карта: ID экрана -> пункты, открывающие его от корня
+	actions map[string][][]Item // Карта: действие -> пути к пунктам, которые его запускают (вместе с ними)
+}
+
+// NewTree проверяет дерево меню и строит индексы
+func NewTree(root *Menu) (*Tree, error) {
+	tree := &Tree{
+		Root:    root,
+		menus:   make(map[string]*Menu),
+		paths:   make(map[string][]Item),
+		actions: make(map[string][][]Item),
+	}
+
+	if err := tree.index(root, nil); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// Load читает дерево меню из YAML- или JSON-файла (формат определяется по расширению)
+func Load(path string) (*Tree, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root Menu
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &root)
+	case ".json":
+		err = json.Unmarshal(data, &root)
+	default:
+		return nil, fmt.Errorf("неизвестный формат файла меню: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора файла меню %s: %w", path, err)
+	}
+
+	return NewTree(&root)
+}
+
+// Menu возвращает экран меню по ID
+func (t *Tree) Menu(id string) (*Menu, bool) {
+	menu, exists := t.menus[id]
+	return menu, exists
+}
+
+// MenuFor возвращает экран меню, если пользователь с указанными ролями может его открыть:
+// виден пункт, открывающий экран, и все пункты на пути к нему от корня
+func (t *Tree) MenuFor(id string, roles []string) (*Menu, bool) {
+	menu, exists := t.menus[id]
+	if !exists || !pathVisibleTo(t.paths[id], roles) {
+		return nil, false
+	}
+	return menu, true
+}
+
+// ActionVisibleTo сообщает, может ли пользователь с указанными ролями запустить действие.
+// Если действие встречается в меню несколько раз, достаточно одного доступного пункта
+func (t *Tree) ActionVisibleTo(name string, roles []string) bool {
+	for _, path := range t.actions[name] {
+		if pathVisibleTo(path, roles) {
+			return true
+		}
+	}
+	return false
+}
+
+// Actions возвращает имена всех действий, упомянутых в меню
+func (t *Tree) Actions() []string {
+	names := make([]string, 0, len(t.actions))
+	for name := range t.actions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// index рекурсивно проверяет экран и добавляет его в индексы.
+// path — пункты, через которые экран открывается от корня
+func (t *Tree) index(menu *Menu, path []Item) error {
+	if err := checkID("меню", menu.ID); err != nil {
+		return err
+	}
+	if _, exists := t.menus[menu.ID]; exists {
+		return fmt.Errorf("меню %q объявлено дважды", menu.ID)
+	}
+	t.menus[menu.ID] = menu
+	t.paths[menu.ID] = path
+
+	for i, item := range menu.Items {
+		where := fmt.Sprintf("меню %q, пункт %d", menu.ID, i+1)
+		if item.Title == "" {
+			return fmt.Errorf("%s: пустая подпись", where)
+		}
+
+		targets := 0
+		for _, set := range []bool{item.Menu != nil, item.Action != "", item.URL != ""} {
+			if set {
+				targets++
+			}
+		}
+		if targets != 1 {
+			return fmt.Errorf("%s (%s): нужно указать ровно одно из menu, action или url", where, item.Title)
+		}
+
+		itemPath := append(slices.Clone(path), item)
+		switch {
+		case item.Menu != nil:
+			if err := t.index(item.Menu, itemPath); err != nil {
+				return err
+			}
+		case item.Action != "":
+			if err := checkID("действие", item.Action); err != nil {
+				return fmt.Errorf("%s: %w", where, err)
+			}
+			t.actions[item.Action] = append(t.actions[item.Action], itemPath)
+		}
+	}
+
+	return nil
+}
+
+// pathVisibleTo сообщает, что все пункты пути видны пользователю с указанными ролями
+func pathVisibleTo(path []Item, roles []string) bool {
+	for _, item := range path {
+		if !item.VisibleTo(roles) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkID проверяет, что идентификатор поместится в callback-данные
+func checkID(kind, id string) error {
+	switch {
+	case id == "":
+		return errors.New(kind + ": пустой идентификатор")
+	case len(id) > maxIDLength:
+		return fmt.Errorf("%s %q: идентификатор длиннее %d байт", kind, id, maxIDLength)
+	case strings.Contains(id, ":"):
+		return fmt.Errorf("%s %q: идентификатор не может содержать ':'", kind, id)
+	}
+	return nil
+}
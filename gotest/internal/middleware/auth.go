@@ -1,24 +1,23 @@
 package middleware
 
 import (
-	"fmt"
-	"slices"
+	"telegram-bot/internal/i18n"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-// IsAdmin проверяет, является ли пользователь администратором
-func IsAdmin(userID int64, adminIDs []int64) bool {
-	return slices.Contains(adminIDs, userID)
+// AdminChecker проверяет права администратора: владельцев бота (ADMIN_IDS)
+// и администраторов, назначенных через бота. Реализуется admins.Service
+type AdminChecker interface {
+	IsAdmin(userID int64) bool
 }
 
 // RequireAdmin проверяет права доступа и отправляет сообщение, если пользователь не админ
-func RequireAdmin(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, adminIDs []int64) bool {
+func RequireAdmin(bot *tgbotapi.BotAPI, msg *tgbotapi.Message, admins AdminChecker, tr *i18n.Translator) bool {
 	userID := msg.From.ID
 
-	if !IsAdmin(userID, adminIDs) {
-		fmt.Println(adminIDs)
-		reply := tgbotapi.NewMessage(msg.Chat.ID, "У вас нет прав для выполнения этой команды.")
+	if !admins.IsAdmin(userID) {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, tr.T("auth.forbidden"))
 		bot.Send(reply)
 		return false
 	}
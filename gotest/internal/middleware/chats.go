@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// ChatTracker ведёт реестр групп и каналов, в которых состоит бот.
+// Источники: обновления my_chat_member (бота добавили, удалили, изменили права),
+// сервисные сообщения о переходе группы в супергруппу и о смене названия
+type ChatTracker struct {
+	chats repository.ChatRepository
+}
+
+// NewChatTracker создаёт новый трекер чатов
+func NewChatTracker(chats repository.ChatRepository) *ChatTracker {
+	return &ChatTracker{
+		chats: chats,
+	}
+}
+
+// Track обновляет реестр чатов по обновлению
+func (t *ChatTracker) Track(update tgbotapi.Update) {
+	if member := update.MyChatMember; member != nil && !member.Chat.IsPrivate() {
+		t.trackMembership(member)
+		return
+	}
+
+	msg := update.Message
+	if msg == nil || msg.Chat == nil || msg.Chat.IsPrivate() {
+		return
+	}
+
+	switch {
+	case msg.MigrateToChatID != 0:
+		t.migrate(msg.Chat.ID, msg.MigrateToChatID)
+	case msg.MigrateFromChatID != 0:
+		t.migrate(msg.MigrateFromChatID, msg.Chat.ID)
+	case msg.NewChatTitle != "":
+		t.rename(msg.Chat.ID, msg.NewChatTitle)
+	}
+}
+
+// trackMembership сохраняет новый статус и права бота в чате
+func (t *ChatTracker) trackMembership(member *tgbotapi.ChatMemberUpdated) {
+	ctx := context.Background()
+	now := time.Now()
+	status := member.NewChatMember
+
+	chat := &domain.Chat{
+		ID:       member.Chat.ID,
+		JoinedAt: now,
+	}
+	existing, err := t.chats.GetByID(ctx, member.Chat.ID)
+	switch {
+	case err == nil:
+		chat = existing
+	case !errors.Is(err, repository.ErrNotFound):
+		log.Printf("Ошибка чтения чата %d: %v", member.Chat.ID, err)
+		return
+	}
+
+	wasActive := chat.IsActive()
+	chat.Type = member.Chat.Type
+	chat.Title = member.Chat.Title
+	chat.Username = member.Chat.UserName
+	chat.BotStatus = status.Status
+	chat.CanSendMessages = canSendMessages(member.Chat.Type, status)
+	chat.CanDeleteMessages = status.CanDeleteMessages
+	chat.CanRestrictMembers = status.CanRestrictMembers
+	chat.CanPinMessages = status.CanPinMessages
+	chat.CanInviteUsers = status.CanInviteUsers
+	chat.UpdatedAt = now
+
+	switch {
+	case chat.IsActive() && !wasActive:
+		// Бота добавили (или добавили снова после удаления)
+		chat.JoinedAt = now
+		chat.LeftAt = time.Time{}
+		log.Printf("Бот добавлен в чат %d (%s) со статусом %s", chat.ID, chat.Title, chat.BotStatus)
+	case !chat.IsActive() && wasActive:
+		chat.LeftAt = now
+		log.Printf("Бот покинул чат %d (%s): %s", chat.ID, chat.Title, chat.BotStatus)
+	}
+
+	if err := t.chats.Save(ctx, chat); err != nil {
+		log.Printf("Ошибка сохранения чата %d: %v", chat.ID, err)
+	}
+}
+
+// migrate переносит чат на ID супергруппы
+func (t *ChatTracker) migrate(fromID, toID int64) {
+	if err := t.chats.Migrate(context.Background(), fromID, toID); err != nil {
+		log.Printf("Ошибка переноса чата %d в %d: %v", fromID, toID, err)
+	}
+}
+
+// rename сохраняет новое название чата
+func (t *ChatTracker) rename(id int64, title string) {
+	ctx := context.Background()
+
+	chat, err := t.chats.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("Ошибка чтения чата %d: %v", id, err)
+		return
+	}
+
+	chat.Title = title
+	chat.UpdatedAt = time.Now()
+	if err := t.chats.Save(ctx, chat); err != nil {
+		log.Printf("Ошибка сохранения чата %d: %v", id, err)
+	}
+}
+
+// canSendMessages определяет, может ли бот с таким статусом писать в чат
+func canSendMessages(chatType string, member tgbotapi.ChatMember) bool {
+	switch member.Status {
+	case domain.ChatStatusCreator, domain.ChatStatusAdministrator:
+		// В канале писать может только администратор с правом публикации
+		return chatType != domain.ChatTypeChannel || member.CanPostMessages
+	case domain.ChatStatusMember:
+		return chatType != domain.ChatTypeChannel
+	case domain.ChatStatusRestricted:
+		return member.IsMember && member.CanSendMessages
+	default:
+		return false
+	}
+}
@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Ограничения журнала взаимодействий
+const (
+	interactionBuffer    = 1024      // Сколько записей может ждать сохранения
+	interactionTextLimit = 1024      // Сколько символов текста сохранять
+	interactionPruneTick = time.Hour // Как часто удалять устаревшие записи
+)
+
+// InteractionLog сохраняет каждое обновление и результат его обработки в журнал.
+// Записи сохраняются в фоне, чтобы запись в БД не задерживала ответ пользователю
+type InteractionLog struct {
+	repo      repository.InteractionRepository
+	retention time.Duration // Сколько хранить записи (0 — бессрочно)
+	entries   chan queuedInteraction
+
+	mu     sync.Mutex
+	erased map[int64]time.Time // Карта: ID пользователя -> когда удалены его данные
+}
+
+// queuedInteraction — запись, ожидающая сохранения
+type queuedInteraction struct {
+	entry    *domain.Interaction
+	received time.Time // Когда бот начал обрабатывать обновление
+}
+
+// NewInteractionLog создаёт журнал взаимодействий. Сохранять записи начинает Run
+func NewInteractionLog(repo repository.InteractionRepository, retention time.Duration) *InteractionLog {
+	return &InteractionLog{
+		repo:      repo,
+		retention: retention,
+		entries:   make(chan queuedInteraction, interactionBuffer),
+		erased:    make(map[int64]time.Time),
+	}
+}
+
+// Run сохраняет записи и раз в час удаляет записи старше срока хранения.
+// Запускается в отдельной горутине
+func (l *InteractionLog) Run() {
+	prune := time.NewTicker(interactionPruneTick)
+	defer prune.Stop()
+
+	l.prune()
+	for {
+		select {
+		case queued := <-l.entries:
+			l.save(queued)
+		case <-prune.C:
+			l.prune()
+		}
+	}
+}
+
+// Record ставит в очередь запись об обработанном обновлении.
+// handler — кто обработал обновление (пусто — обработчика не нашлось)
+func (l *InteractionLog) Record(update tgbotapi.Update, handler string, latency time.Duration, err error) {
+	entry := NewInteraction(update)
+	entry.Handler = handler
+	entry.Latency = latency
+
+	switch {
+	case err != nil:
+		entry.Outcome = domain.OutcomeError
+		entry.Error = truncate(err.Error(), interactionTextLimit)
+	case handler == "":
+		entry.Outcome = domain.OutcomeIgnored
+	default:
+		entry.Outcome = domain.OutcomeOK
+	}
+
+	select {
+	case l.entries <- queuedInteraction{entry: entry, received: time.Now().Add(-latency)}:
+	default:
+		log.Printf("Очередь журнала взаимодействий переполнена, запись об обновлении %d пропущена", update.UpdateID)
+	}
+}
+
+// Forget отмечает, что данные пользователя удаляются: записи об обновлениях,
+// полученных до этого момента (ждущие в очереди и само обновление с запросом
+// на удаление), не сохраняются. Более поздние обновления пишутся как обычно.
+// Вызывается до удаления журнала пользователя: если запись сохраняется прямо
+// сейчас, Forget дождётся её, и она будет удалена вместе с остальными
+func (l *InteractionLog) Forget(userID int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.erased[userID] = time.Now()
+}
+
+// save сохраняет запись, если данные пользователя не были удалены после её получения
+func (l *InteractionLog) save(queued queuedInteraction) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if erasedAt, exists := l.erased[queued.entry.UserID]; exists && !queued.received.After(erasedAt) {
+		return
+	}
+	if err := l.repo.Add(context.Background(), queued.entry); err != nil {
+		log.Printf("Ошибка записи в журнал взаимодействий: %v", err)
+	}
+}
+
+// prune удаляет записи старше срока хранения
+func (l *InteractionLog) prune() {
+	// К этому времени очередь давно сохранена — отметки об удалении больше не нужны
+	l.mu.Lock()
+	for userID, erasedAt := range l.erased {
+		if time.Since(erasedAt) > interactionPruneTick {
+			delete(l.erased, userID)
+		}
+	}
+	l.mu.Unlock()
+
+	if l.retention <= 0 {
+		return
+	}
+
+	count, err := l.repo.DeleteBefore(context.Background(), time.Now().Add(-l.retention))
+	if err != nil {
+		log.Printf("Ошибка очистки журнала взаимодействий: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("Из журнала взаимодействий удалено устаревших записей: %d", count)
+	}
+}
+
+// NewInteraction описывает обновление записью журнала (без результата обработки)
+func NewInteraction(update tgbotapi.Update) *domain.Interaction {
+	entry := &domain.Interaction{
+		UpdateID:   update.UpdateID,
+		UpdateType: updateType(update),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if from := update.SentFrom(); from != nil {
+		entry.UserID = from.ID
+	}
+
+	msg := update.Message
+	if msg == nil {
+		msg = update.EditedMessage
+	}
+	if msg == nil {
+		msg = update.ChannelPost
+	}
+
+	switch {
+	case msg != nil:
+		entry.ChatID = msg.Chat.ID
+		entry.Command = msg.Command()
+		entry.Text = msg.Text
+		if entry.Text == "" {
+			entry.Text = msg.Caption
+		}
+		entry.FileID = messageFileID(msg)
+	case update.CallbackQuery != nil:
+		entry.Text = update.CallbackQuery.Data
+		if update.CallbackQuery.Message != nil {
+			entry.ChatID = update.CallbackQuery.Message.Chat.ID
+		}
+	case update.MyChatMember != nil:
+		entry.ChatID = update.MyChatMember.Chat.ID
+		entry.Text = update.MyChatMember.NewChatMember.Status
+	case update.InlineQuery != nil:
+		entry.Text = update.InlineQuery.Query
+	}
+
+	entry.Text = truncate(entry.Text, interactionTextLimit)
+	return entry
+}
+
+// updateType возвращает тип обновления так, как он называется в Bot API
+func updateType(update tgbotapi.Update) string {
+	switch {
+	case update.Message != nil:
+		return "message"
+	case update.EditedMessage != nil:
+		return "edited_message"
+	case update.ChannelPost != nil:
+		return "channel_post"
+	case update.EditedChannelPost != nil:
+		return "edited_channel_post"
+	case update.CallbackQuery != nil:
+		return "callback_query"
+	case update.InlineQuery != nil:
+		return "inline_query"
+	case update.ChosenInlineResult != nil:
+		return "chosen_inline_result"
+	case update.MyChatMember != nil:
+		return "my_chat_member"
+	case update.ChatMember != nil:
+		return "chat_member"
+	case update.ChatJoinRequest != nil:
+		return "chat_join_request"
+	case update.ShippingQuery != nil:
+		return "shipping_query"
+	case update.PreCheckoutQuery != nil:
+		return "pre_checkout_query"
+	case update.Poll != nil:
+		return "poll"
+	case update.PollAnswer != nil:
+		return "poll_answer"
+	default:
+		return "unknown"
+	}
+}
+
+// messageFileID возвращает File ID вложения сообщения (у фото — самого крупного размера)
+func messageFileID(msg *tgbotapi.Message) string {
+	switch {
+	case msg.Document != nil:
+		return msg.Document.FileID
+	case len(msg.Photo) > 0:
+		return msg.Photo[len(msg.Photo)-1].FileID
+	case msg.Video != nil:
+		return msg.Video.FileID
+	case msg.Audio != nil:
+		return msg.Audio.FileID
+	case msg.Voice != nil:
+		return msg.Voice.FileID
+	case msg.VideoNote != nil:
+		return msg.VideoNote.FileID
+	case msg.Animation != nil:
+		return msg.Animation.FileID
+	case msg.Sticker != nil:
+		return msg.Sticker.FileID
+	default:
+		return ""
+	}
+}
+
+// truncate обрезает строку до limit символов
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit]) + "…"
+}
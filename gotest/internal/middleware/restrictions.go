@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// restrictionNoticeInterval — не чаще чем раз в столько пользователь с запретом писать получает отказ
+const restrictionNoticeInterval = time.Minute
+
+// RestrictionChecker сообщает, ограничен ли пользователь (реализует moderation.Service)
+type RestrictionChecker interface {
+	Restriction(userID int64) (domain.Restriction, bool)
+}
+
+// RestrictionGuard не пропускает к обработчикам обновления ограниченных пользователей.
+// Обновления заблокированных пользователей отбрасываются молча. Пользователю
+// с запретом писать бот вежливо отказывает: в личном чате — сообщением (не чаще
+// раза в минуту), на нажатие кнопки — всплывающей подсказкой. Администраторов
+// ограничения не касаются
+type RestrictionGuard struct {
+	checker RestrictionChecker
+	admins  AdminChecker
+	loc     *i18n.Localizer
+
+	mu      sync.Mutex
+	noticed map[int64]time.Time // Карта: ID пользователя -> когда ему последний раз отказали
+}
+
+// NewRestrictionGuard создаёт фильтр обновлений ограниченных пользователей
+func NewRestrictionGuard(checker RestrictionChecker, admins AdminChecker, loc *i18n.Localizer) *RestrictionGuard {
+	return &RestrictionGuard{
+		checker: checker,
+		admins:  admins,
+		loc:     loc,
+		noticed: make(map[int64]time.Time),
+	}
+}
+
+// Check проверяет отправителя обновления. Если он ограничен, возвращает вид ограничения
+// и true — такое обновление обрабатывать не нужно (отказ уже отправлен)
+func (g *RestrictionGuard) Check(bot *tgbotapi.BotAPI, update tgbotapi.Update) (string, bool) {
+	from := update.SentFrom()
+	if from == nil || g.admins.IsAdmin(from.ID) {
+		return "", false
+	}
+
+	restriction, restricted := g.checker.Restriction(from.ID)
+	if !restricted {
+		return "", false
+	}
+	if restriction.Kind == domain.RestrictionMute {
+		g.refuse(bot, update, from, restriction)
+	}
+	return restriction.Kind, true
+}
+
+// refuse объясняет пользователю с запретом писать, почему бот ему не отвечает
+func (g *RestrictionGuard) refuse(bot *tgbotapi.BotAPI, update tgbotapi.Update, from *tgbotapi.User, restriction domain.Restriction) {
+	tr := g.loc.For(from)
+
+	text := tr.T("restriction.muted_forever")
+	if !restriction.IsPermanent() {
+		text = tr.T("restriction.muted", i18n.Args{"left": tr.Duration(time.Until(restriction.ExpiresAt))})
+	}
+	if restriction.Reason != "" {
+		text += "\n" + tr.T("restriction.reason", i18n.Args{"reason": restriction.Reason})
+	}
+
+	var err error
+	switch {
+	case update.CallbackQuery != nil:
+		// На нажатие нужно ответить в любом случае, иначе кнопка останется в состоянии загрузки
+		_, err = bot.Request(tgbotapi.NewCallback(update.CallbackQuery.ID, text))
+	case update.Message != nil && update.Message.Chat.IsPrivate() && g.shouldNotice(from.ID):
+		_, err = bot.Send(tgbotapi.NewMessage(update.Message.Chat.ID, text))
+	}
+	if err != nil {
+		log.Printf("Ошибка отправки отказа пользователю %d: %v", from.ID, err)
+	}
+}
+
+// shouldNotice сообщает, пора ли снова отказать пользователю сообщением
+func (g *RestrictionGuard) shouldNotice(userID int64) bool {
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if last, exists := g.noticed[userID]; exists && now.Sub(last) < restrictionNoticeInterval {
+		return false
+	}
+	for id, last := range g.noticed {
+		if now.Sub(last) >= restrictionNoticeInterval {
+			delete(g.noticed, id)
+		}
+	}
+	g.noticed[userID] = now
+	return true
+}
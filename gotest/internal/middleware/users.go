@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// UserTracker сохраняет отправителя каждого обновления в репозиторий пользователей
+type UserTracker struct {
+	users repository.UserRepository
+}
+
+// NewUserTracker создаёт новый трекер пользователей
+func NewUserTracker(users repository.UserRepository) *UserTracker {
+	return &UserTracker{
+		users: users,
+	}
+}
+
+// Track обновляет данные отправителя и время его последнего обращения.
+// Обновление my_chat_member в личном чате означает, что пользователь
+// заблокировал бота (статус kicked) или снова запустил его
+func (t *UserTracker) Track(update tgbotapi.Update) {
+	from := update.SentFrom()
+	blocked := false
+
+	if member := update.MyChatMember; member != nil && member.Chat.IsPrivate() {
+		from = &member.From
+		blocked = member.NewChatMember.WasKicked()
+	}
+
+	if from == nil || from.IsBot {
+		return
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		ID:           from.ID,
+		Username:     from.UserName,
+		FirstName:    from.FirstName,
+		LastName:     from.LastName,
+		LanguageCode: from.LanguageCode,
+		IsBlocked:    blocked,
+		FirstSeenAt:  now,
+		LastSeenAt:   now,
+	}
+
+	if err := t.users.Upsert(context.Background(), user); err != nil {
+		log.Printf("Ошибка сохранения пользователя %d: %v", from.ID, err)
+	}
+}
+
+// ReportError отмечает пользователя заблокировавшим бота, если ответ
+// на его обновление не удалось отправить с ошибкой 403
+func (t *UserTracker) ReportError(update tgbotapi.Update, err error) {
+	if !IsBlockedError(err) {
+		return
+	}
+
+	// Ответ отправляется в чат обновления; нас интересуют только личные чаты
+	var chat *tgbotapi.Chat
+	switch {
+	case update.Message != nil:
+		chat = update.Message.Chat
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		chat = update.CallbackQuery.Message.Chat
+	}
+	from := update.SentFrom()
+	if from == nil || chat == nil || !chat.IsPrivate() {
+		return
+	}
+
+	if err := t.users.SetBlocked(context.Background(), from.ID, true); err != nil {
+		log.Printf("Ошибка отметки пользователя %d как заблокировавшего бота: %v", from.ID, err)
+	}
+}
+
+// IsBlockedError сообщает, что Telegram отказал в отправке (403 Forbidden):
+// пользователь заблокировал бота или удалил свой аккаунт
+func IsBlockedError(err error) bool {
+	var apiErr *tgbotapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden
+}
@@ -0,0 +1,193 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// pruneTick — как часто удалять истёкшие ограничения из памяти и хранилища
+const pruneTick = time.Hour
+
+// Service накладывает и снимает ограничения пользователей.
+// Ограничение проверяется на каждом обновлении, поэтому действующие ограничения
+// держатся в памяти: хранилище читается только при запуске (Load)
+type Service struct {
+	store *repository.Store
+
+	mu           sync.RWMutex
+	restrictions map[int64]domain.Restriction // Карта: ID пользователя -> действующее ограничение
+}
+
+// NewService создаёт сервис ограничений. Действующие ограничения загружает Load
+func NewService(store *repository.Store) *Service {
+	return &Service{
+		store:        store,
+		restrictions: make(map[int64]domain.Restriction),
+	}
+}
+
+// Load загружает действующие ограничения из хранилища
+func (s *Service) Load(ctx context.Context) error {
+	restrictions, err := s.store.Restrictions.ListActive(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки ограничений пользователей: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.restrictions)
+	for _, restriction := range restrictions {
+		s.restrictions[restriction.UserID] = restriction
+	}
+	return nil
+}
+
+// Run раз в час удаляет истёкшие ограничения. Запускается в отдельной горутине
+func (s *Service) Run() {
+	ticker := time.NewTicker(pruneTick)
+	defer ticker.Stop()
+
+	s.prune()
+	for range ticker.C {
+		s.prune()
+	}
+}
+
+// Restriction возвращает действующее ограничение пользователя.
+// Истёкшее ограничение сразу убирается из памяти; из хранилища его удалит prune
+func (s *Service) Restriction(userID int64) (domain.Restriction, bool) {
+	now := time.Now()
+
+	s.mu.RLock()
+	restriction, exists := s.restrictions[userID]
+	s.mu.RUnlock()
+
+	if !exists {
+		return domain.Restriction{}, false
+	}
+	if !restriction.IsActiveAt(now) {
+		s.mu.Lock()
+		// Пока блокировка была снята, администратор мог наложить новое ограничение
+		if current, exists := s.restrictions[userID]; exists && !current.IsActiveAt(now) {
+			delete(s.restrictions, userID)
+		}
+		s.mu.Unlock()
+		return domain.Restriction{}, false
+	}
+	return restriction, true
+}
+
+// prune удаляет истёкшие ограничения из памяти и из хранилища
+func (s *Service) prune() {
+	now := time.Now()
+
+	s.mu.Lock()
+	for userID, restriction := range s.restrictions {
+		if !restriction.IsActiveAt(now) {
+			delete(s.restrictions, userID)
+		}
+	}
+	s.mu.Unlock()
+
+	count, err := s.store.Restrictions.DeleteExpired(context.Background(), now)
+	if err != nil {
+		log.Printf("Ошибка удаления истёкших ограничений: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("Удалено истёкших ограничений пользователей: %d", count)
+	}
+}
+
+// Restrict накладывает ограничение и записывает это в журнал аудита.
+// Прежнее ограничение пользователя заменяется
+func (s *Service) Restrict(ctx context.Context, restriction domain.Restriction) error {
+	restriction.CreatedAt = time.Now().UTC()
+
+	err := s.store.InTx(ctx, func(tx *repository.Store) error {
+		if err := tx.Restrictions.Save(ctx, &restriction); err != nil {
+			return err
+		}
+		return tx.Audit.Add(ctx, &domain.AuditEntry{
+			Action:    domain.AuditUserRestricted,
+			ActorID:   restriction.CreatedBy,
+			SubjectID: restriction.UserID,
+			Details:   describe(restriction),
+			CreatedAt: restriction.CreatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка ограничения пользователя %d: %w", restriction.UserID, err)
+	}
+
+	s.mu.Lock()
+	s.restrictions[restriction.UserID] = restriction
+	s.mu.Unlock()
+
+	log.Printf("Администратор %d ограничил пользователя %d: %s", restriction.CreatedBy, restriction.UserID, describe(restriction))
+	return nil
+}
+
+// Lift снимает ограничение с пользователя. Возвращает false, если действующего ограничения не было
+func (s *Service) Lift(ctx context.Context, userID, actorID int64) (bool, error) {
+	_, active := s.Restriction(userID)
+
+	var deleted bool
+	err := s.store.InTx(ctx, func(tx *repository.Store) error {
+		var err error
+		deleted, err = tx.Restrictions.Delete(ctx, userID)
+		if err != nil || !deleted {
+			return err
+		}
+		return tx.Audit.Add(ctx, &domain.AuditEntry{
+			Action:    domain.AuditUserUnrestricted,
+			ActorID:   actorID,
+			SubjectID: userID,
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return false, fmt.Errorf("ошибка снятия ограничения с пользователя %d: %w", userID, err)
+	}
+
+	s.mu.Lock()
+	delete(s.restrictions, userID)
+	s.mu.Unlock()
+
+	if deleted {
+		log.Printf("Администратор %d снял ограничение с пользователя %d", actorID, userID)
+	}
+	return deleted && active, nil
+}
+
+// Forget убирает из памяти причину ограничения пользователя, удалившего свои данные.
+// Само ограничение продолжает действовать: удаление данных его не снимает
+func (s *Service) Forget(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if restriction, exists := s.restrictions[userID]; exists {
+		restriction.Reason = ""
+		s.restrictions[userID] = restriction
+	}
+}
+
+// describe описывает ограничение для журнала аудита
+func describe(restriction domain.Restriction) string {
+	parts := []string{restriction.Kind}
+	if !restriction.IsPermanent() {
+		parts = append(parts, "до "+restriction.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if restriction.Reason != "" {
+		parts = append(parts, "причина: "+restriction.Reason)
+	}
+	return strings.Join(parts, ", ")
+}
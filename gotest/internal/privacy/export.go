@@ -0,0 +1,363 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// exportPageSize — по сколько записей журнала читать за один запрос
+const exportPageSize = 500
+
+// Export — всё, что бот хранит о пользователе
+type Export struct {
+	GeneratedAt time.Time            `json:"generated_at"`      // Когда выгрузка собрана
+	User        *domain.User         `json:"user"`              // Профиль (nil — профиль не сохранён)
+	Settings    *domain.UserSettings `json:"settings"`          // Настройки (nil — пользователь их не менял)
+	Session     *SessionExport       `json:"session,omitempty"` // Незавершённый сценарий
+	Audit       []domain.AuditEntry  `json:"audit"`             // Записи журнала аудита о данных пользователя
+	Messages    []domain.Interaction `json:"messages"`          // Журнал взаимодействий: сообщения, команды, нажатия кнопок
+	Deliveries  []domain.Delivery    `json:"deliveries"`        // Доставка рассылок в личный чат
+	Restriction *domain.Restriction  `json:"restriction"`       // Действующее ограничение (nil — ограничений нет)
+	Admin       *domain.Admin        `json:"admin"`             // Права администратора, выданные /grant (nil — не выдавались)
+}
+
+// SessionExport — незавершённый сценарий диалога с пользователем
+type SessionExport struct {
+	Flow      string            `json:"flow"`       // Сценарий
+	Step      string            `json:"step"`       // Шаг сценария
+	Data      map[string]string `json:"data"`       // Собранные сценарием данные
+	UpdatedAt time.Time         `json:"updated_at"` // Когда сессия последний раз менялась
+}
+
+// Export собирает данные пользователя из всех хранилищ
+func (s *Service) Export(ctx context.Context, userID int64) (*Export, error) {
+	export := &Export{
+		GeneratedAt: time.Now().UTC(),
+		Audit:       []domain.AuditEntry{},
+		Messages:    []domain.Interaction{},
+		Deliveries:  []domain.Delivery{},
+	}
+
+	user, err := s.store.Users.GetByID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("ошибка чтения профиля: %w", err)
+	}
+	export.User = user
+
+	settings, err := s.store.Settings.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("ошибка чтения настроек: %w", err)
+	}
+	export.Settings = settings
+
+	if sess := s.sessions.Get(userID); sess.Active() || len(sess.Data) > 0 {
+		export.Session = &SessionExport{
+			Flow:      sess.Flow,
+			Step:      sess.Step,
+			Data:      sess.Data,
+			UpdatedAt: sess.UpdatedAt.UTC(),
+		}
+	}
+
+	audit, err := s.store.Audit.ListBySubject(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала аудита: %w", err)
+	}
+	if audit != nil {
+		export.Audit = audit
+	}
+
+	filter := repository.InteractionFilter{UserID: userID}
+	for offset := 0; ; offset += exportPageSize {
+		messages, err := s.store.Interactions.List(ctx, filter, offset, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения журнала взаимодействий: %w", err)
+		}
+		export.Messages = append(export.Messages, messages...)
+		if len(messages) < exportPageSize {
+			break
+		}
+	}
+
+	// ID личного чата совпадает с ID пользователя
+	deliveries, err := s.store.Broadcasts.Deliveries(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения доставки рассылок: %w", err)
+	}
+	if deliveries != nil {
+		export.Deliveries = deliveries
+	}
+
+	restriction, err := s.store.Restrictions.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("ошибка чтения ограничения: %w", err)
+	}
+	export.Restriction = restriction
+
+	admin, err := s.store.Admins.Get(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("ошибка чтения прав администратора: %w", err)
+	}
+	export.Admin = admin
+
+	return export, nil
+}
+
+// LastExport возвращает время последней выгрузки данных пользователя
+// (нулевое — выгрузок не было). Выгрузки записываются в журнал аудита,
+// поэтому ограничение частоты переживает перезапуск бота
+func (s *Service) LastExport(ctx context.Context, userID int64) (time.Time, error) {
+	entries, err := s.store.Audit.ListBySubject(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ошибка чтения журнала аудита: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Action == domain.AuditUserExported {
+			return entry.CreatedAt, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+// RecordExport записывает выгрузку данных в журнал аудита
+func (s *Service) RecordExport(ctx context.Context, userID int64, format string) error {
+	err := s.store.Audit.Add(ctx, &domain.AuditEntry{
+		Action:    domain.AuditUserExported,
+		ActorID:   userID,
+		SubjectID: userID,
+		Details:   format,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка записи выгрузки в журнал аудита: %w", err)
+	}
+	return nil
+}
+
+// JSON кодирует выгрузку в JSON с отступами
+func (e *Export) JSON() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// Zip упаковывает выгрузку в zip-архив: data.json и по CSV-файлу на каждый раздел
+func (e *Export) Zip() ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	data, err := e.JSON()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(archive, "data.json", data); err != nil {
+		return nil, err
+	}
+
+	tables := []struct {
+		name   string
+		header []string
+		rows   [][]string
+	}{
+		{"user.csv", userHeader, userRows(e.User)},
+		{"settings.csv", settingsHeader, settingsRows(e.Settings)},
+		{"session.csv", sessionHeader, sessionRows(e.Session)},
+		{"audit.csv", auditHeader, auditRows(e.Audit)},
+		{"messages.csv", messagesHeader, messagesRows(e.Messages)},
+		{"deliveries.csv", deliveriesHeader, deliveriesRows(e.Deliveries)},
+		{"restriction.csv", restrictionHeader, restrictionRows(e.Restriction)},
+		{"admin.csv", adminHeader, adminRows(e.Admin)},
+	}
+	for _, table := range tables {
+		data, err := encodeCSV(table.header, table.rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка записи %s: %w", table.name, err)
+		}
+		if err := writeZipFile(archive, table.name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Заголовки CSV-файлов выгрузки
+var (
+	userHeader        = []string{"id", "username", "first_name", "last_name", "language_code", "is_blocked", "first_seen_at", "last_seen_at"}
+	settingsHeader    = []string{"language", "notifications_enabled", "time_zone", "quiet_start", "quiet_end", "updated_at"}
+	sessionHeader     = []string{"flow", "step", "key", "value", "updated_at"}
+	auditHeader       = []string{"id", "action", "actor_id", "details", "created_at"}
+	messagesHeader    = []string{"id", "update_type", "chat_id", "command", "text", "file_id", "handler", "latency_ms", "outcome", "created_at"}
+	deliveriesHeader  = []string{"broadcast_id", "chat_id", "status", "error", "sent_at"}
+	restrictionHeader = []string{"kind", "reason", "created_by", "created_at", "expires_at"}
+	adminHeader       = []string{"granted_by", "granted_at"}
+)
+
+// userRows — строки user.csv
+func userRows(user *domain.User) [][]string {
+	if user == nil {
+		return nil
+	}
+	return [][]string{{
+		strconv.FormatInt(user.ID, 10),
+		user.Username,
+		user.FirstName,
+		user.LastName,
+		user.LanguageCode,
+		strconv.FormatBool(user.IsBlocked),
+		formatTime(user.FirstSeenAt),
+		formatTime(user.LastSeenAt),
+	}}
+}
+
+// settingsRows — строки settings.csv
+func settingsRows(settings *domain.UserSettings) [][]string {
+	if settings == nil {
+		return nil
+	}
+	return [][]string{{
+		settings.Language,
+		strconv.FormatBool(settings.NotificationsEnabled),
+		settings.TimeZone,
+		strconv.Itoa(settings.QuietStart),
+		strconv.Itoa(settings.QuietEnd),
+		formatTime(settings.UpdatedAt),
+	}}
+}
+
+// sessionRows — строки session.csv: по строке на каждое значение сессии
+func sessionRows(sess *SessionExport) [][]string {
+	if sess == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(sess.Data))
+	for key := range sess.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := [][]string{{sess.Flow, sess.Step, "", "", formatTime(sess.UpdatedAt)}}
+	for _, key := range keys {
+		rows = append(rows, []string{sess.Flow, sess.Step, key, sess.Data[key], formatTime(sess.UpdatedAt)})
+	}
+	return rows
+}
+
+// auditRows — строки audit.csv
+func auditRows(entries []domain.AuditEntry) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			strconv.FormatInt(entry.ID, 10),
+			entry.Action,
+			strconv.FormatInt(entry.ActorID, 10),
+			entry.Details,
+			formatTime(entry.CreatedAt),
+		})
+	}
+	return rows
+}
+
+// messagesRows — строки messages.csv
+func messagesRows(messages []domain.Interaction) [][]string {
+	rows := make([][]string, 0, len(messages))
+	for _, message := range messages {
+		rows = append(rows, []string{
+			strconv.FormatInt(message.ID, 10),
+			message.UpdateType,
+			strconv.FormatInt(message.ChatID, 10),
+			message.Command,
+			message.Text,
+			message.FileID,
+			message.Handler,
+			strconv.FormatInt(message.Latency.Milliseconds(), 10),
+			message.Outcome,
+			formatTime(message.CreatedAt),
+		})
+	}
+	return rows
+}
+
+// deliveriesRows — строки deliveries.csv
+func deliveriesRows(deliveries []domain.Delivery) [][]string {
+	rows := make([][]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		rows = append(rows, []string{
+			strconv.FormatInt(delivery.BroadcastID, 10),
+			strconv.FormatInt(delivery.ChatID, 10),
+			delivery.Status,
+			delivery.Error,
+			formatTime(delivery.SentAt),
+		})
+	}
+	return rows
+}
+
+// restrictionRows — строки restriction.csv
+func restrictionRows(restriction *domain.Restriction) [][]string {
+	if restriction == nil {
+		return nil
+	}
+	return [][]string{{
+		restriction.Kind,
+		restriction.Reason,
+		strconv.FormatInt(restriction.CreatedBy, 10),
+		formatTime(restriction.CreatedAt),
+		formatTime(restriction.ExpiresAt),
+	}}
+}
+
+// adminRows — строки admin.csv
+func adminRows(admin *domain.Admin) [][]string {
+	if admin == nil {
+		return nil
+	}
+	return [][]string{{
+		strconv.FormatInt(admin.GrantedBy, 10),
+		formatTime(admin.GrantedAt),
+	}}
+}
+
+// encodeCSV записывает таблицу в CSV
+func encodeCSV(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeZipFile добавляет файл в архив
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("ошибка записи %s в архив: %w", name, err)
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+// formatTime форматирует время для CSV (RFC 3339, UTC); нулевое время — пустая строка
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,129 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/session"
+	"telegram-bot/internal/settings"
+)
+
+// Service выполняет запросы пользователей об их личных данных
+type Service struct {
+	store    *repository.Store
+	sessions *session.Store
+	settings *settings.Service
+	onErase  []func(userID int64) // Вызываются после удаления данных пользователя
+}
+
+// NewService создаёт сервис личных данных
+func NewService(store *repository.Store, sessions *session.Store, settings *settings.Service) *Service {
+	return &Service{
+		store:    store,
+		sessions: sessions,
+		settings: settings,
+	}
+}
+
+// OnErase добавляет функцию, которую Erase вызывает после удаления данных пользователя:
+// так компоненты с собственными буферами и кэшами (журнал взаимодействий, права
+// администраторов, ограничения) забывают пользователя
+func (s *Service) OnErase(forget func(userID int64)) {
+	s.onErase = append(s.onErase, forget)
+}
+
+// erasedDetails — запись в журнале аудита о том, что удалено, а что сохранено
+const erasedDetails = "удалены: профиль, настройки, журнал взаимодействий, доставка рассылок, права администратора; " +
+	"обезличены: причина ограничения, авторство рассылок и сегментов (неотправленные рассылки по расписанию отменены); " +
+	"сохранены: действующее ограничение и журнал аудита"
+
+// Erase удаляет все сохранённые данные пользователя userID по запросу actorID
+// (обычно это сам пользователь) — то же, что выгружает Export.
+// Действующее ограничение не снимается, чтобы удалением данных нельзя было обойти
+// блокировку: у него стирается только причина. Журнал аудита тоже сохраняется.
+// Удаление и запись в журнал аудита выполняются в одной транзакции: либо данные
+// удалены и это записано, либо не изменилось ничего.
+// В журнале остаётся только ID пользователя — без имени и других данных профиля
+func (s *Service) Erase(ctx context.Context, userID, actorID int64) error {
+	now := time.Now()
+
+	err := s.store.InTx(ctx, func(tx *repository.Store) error {
+		if err := tx.Settings.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка удаления настроек: %w", err)
+		}
+		if err := tx.Interactions.DeleteByUser(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка удаления журнала взаимодействий: %w", err)
+		}
+		if err := tx.Broadcasts.DeleteRecipient(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка удаления результатов рассылок: %w", err)
+		}
+		if _, err := tx.Admins.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка удаления прав администратора: %w", err)
+		}
+		if err := s.anonymizeRestriction(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err := tx.Broadcasts.AnonymizeAuthor(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка обезличивания рассылок: %w", err)
+		}
+		if err := tx.Schedules.AnonymizeAuthor(ctx, userID, now); err != nil {
+			return fmt.Errorf("ошибка обезличивания рассылок по расписанию: %w", err)
+		}
+		if err := tx.Segments.AnonymizeAuthor(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка обезличивания сегментов: %w", err)
+		}
+		if err := tx.Users.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("ошибка удаления профиля: %w", err)
+		}
+
+		return tx.Audit.Add(ctx, &domain.AuditEntry{
+			Action:    domain.AuditUserErased,
+			ActorID:   actorID,
+			SubjectID: userID,
+			Details:   erasedDetails,
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка удаления данных пользователя %d: %w", userID, err)
+	}
+
+	// Буферы и кэши забывают пользователя только после успешного удаления:
+	// если транзакция не прошла, данные должны остаться целыми
+	for _, forget := range s.onErase {
+		forget(userID)
+	}
+
+	// Незавершённые сценарии хранятся только в памяти — их тоже забываем,
+	// как и закэшированные настройки
+	s.sessions.Reset(userID)
+	s.settings.Forget(userID)
+
+	log.Printf("Данные пользователя %d удалены по запросу %d", userID, actorID)
+	return nil
+}
+
+// anonymizeRestriction стирает причину ограничения пользователя, не снимая само ограничение
+func (s *Service) anonymizeRestriction(ctx context.Context, tx *repository.Store, userID int64) error {
+	restriction, err := tx.Restrictions.Get(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка чтения ограничения: %w", err)
+	}
+	if restriction.Reason == "" {
+		return nil
+	}
+
+	restriction.Reason = ""
+	if err := tx.Restrictions.Save(ctx, restriction); err != nil {
+		return fmt.Errorf("ошибка обезличивания ограничения: %w", err)
+	}
+	return nil
+}
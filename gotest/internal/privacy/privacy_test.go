@@ -0,0 +1,142 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/database"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+	"telegram-bot/internal/session"
+	"telegram-bot/internal/settings"
+)
+
+// testStores возвращает хранилище в памяти и SQLite в памяти со схемой из миграций
+func testStores(t *testing.T) map[string]*repository.Store {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Open(ctx, config.DatabaseConfig{Driver: database.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	return map[string]*repository.Store{
+		"memory": repository.NewMemoryStore(),
+		"sqlite": repository.NewSQLStore(db),
+	}
+}
+
+func TestEraseMatchesExport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	const userID, ownerID = 42, 1
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			service := NewService(store, session.NewStore(time.Hour), settings.NewService(store.Settings, domain.UserSettings{}))
+			var forgotten []int64
+			service.OnErase(func(userID int64) { forgotten = append(forgotten, userID) })
+
+			must := func(err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			must(store.Users.Upsert(ctx, &domain.User{ID: userID, Username: "erased", FirstSeenAt: now, LastSeenAt: now}))
+			must(store.Settings.Save(ctx, &domain.UserSettings{UserID: userID, Language: "en"}))
+			_, err := store.Admins.Add(ctx, &domain.Admin{UserID: userID, GrantedBy: ownerID, GrantedAt: now})
+			must(err)
+			must(store.Restrictions.Save(ctx, &domain.Restriction{UserID: userID, Kind: domain.RestrictionMute, Reason: "спам", CreatedBy: ownerID, CreatedAt: now}))
+			must(store.Segments.Save(ctx, &domain.Segment{Name: "vip", CreatedBy: userID, UpdatedAt: now}))
+
+			broadcast := domain.Broadcast{AuthorID: userID, SourceChatID: userID, SourceMessageID: 1, Status: domain.BroadcastCompleted, ProgressChatID: userID, CreatedAt: now}
+			must(store.Broadcasts.Create(ctx, &broadcast))
+			must(store.Broadcasts.AddRecipients(ctx, broadcast.ID, []int64{userID, 7}))
+
+			schedule := domain.ScheduledBroadcast{AuthorID: userID, SourceChatID: userID, SourceMessageID: 2, Recurrence: domain.RecurrenceDaily, NextRunAt: now.Add(time.Hour), Status: domain.ScheduleActive, CreatedAt: now, UpdatedAt: now}
+			must(store.Schedules.Create(ctx, &schedule))
+
+			must(service.Erase(ctx, userID, userID))
+
+			export, err := service.Export(ctx, userID)
+			must(err)
+			if export.User != nil || export.Settings != nil || export.Admin != nil || len(export.Deliveries) != 0 {
+				t.Errorf("после удаления выгружаются данные: профиль %v, настройки %v, права %v, доставка %v", export.User, export.Settings, export.Admin, export.Deliveries)
+			}
+
+			// Ограничение продолжает действовать, но без причины
+			restriction, err := store.Restrictions.Get(ctx, userID)
+			if err != nil {
+				t.Fatalf("ограничение снято удалением данных: %v", err)
+			}
+			if restriction.Reason != "" || restriction.Kind != domain.RestrictionMute {
+				t.Errorf("ограничение = %+v, want mute без причины", restriction)
+			}
+
+			segment, err := store.Segments.Get(ctx, "vip")
+			must(err)
+			if segment.CreatedBy != 0 {
+				t.Errorf("автор сегмента = %d, want 0", segment.CreatedBy)
+			}
+			stored, err := store.Broadcasts.GetByID(ctx, broadcast.ID)
+			must(err)
+			if stored.AuthorID != 0 {
+				t.Errorf("автор рассылки = %d, want 0", stored.AuthorID)
+			}
+			if deliveries, err := store.Broadcasts.Deliveries(ctx, 7); err != nil || len(deliveries) != 1 {
+				t.Errorf("доставка другому получателю = %v, %v, want 1", deliveries, err)
+			}
+			scheduled, err := store.Schedules.GetByID(ctx, schedule.ID)
+			must(err)
+			if scheduled.AuthorID != 0 || scheduled.Status != domain.ScheduleCancelled {
+				t.Errorf("рассылка по расписанию: автор %d, состояние %q, want 0 и %q", scheduled.AuthorID, scheduled.Status, domain.ScheduleCancelled)
+			}
+
+			if len(export.Audit) != 1 || export.Audit[0].Action != domain.AuditUserErased || export.Audit[0].Details != erasedDetails {
+				t.Errorf("журнал аудита = %+v", export.Audit)
+			}
+			if len(forgotten) != 1 || forgotten[0] != userID {
+				t.Errorf("OnErase вызван для %v, want [%d]", forgotten, userID)
+			}
+		})
+	}
+}
+
+func TestEraseFailureKeepsBuffers(t *testing.T) {
+	store := repository.NewMemoryStore()
+	store.Users = failingUsers{store.Users}
+
+	service := NewService(store, session.NewStore(time.Hour), settings.NewService(store.Settings, domain.UserSettings{}))
+	called := false
+	service.OnErase(func(int64) { called = true })
+
+	if err := service.Erase(context.Background(), 42, 42); err == nil {
+		t.Fatal("Erase() error = nil, want error")
+	}
+	if called {
+		t.Error("OnErase вызван, хотя удаление не удалось")
+	}
+}
+
+// failingUsers — репозиторий пользователей, который не может удалить пользователя
+type failingUsers struct {
+	repository.UserRepository
+}
+
+func (failingUsers) Delete(context.Context, int64) error {
+	return errors.New("база недоступна")
+}
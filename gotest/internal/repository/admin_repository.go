@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// AdminRepository хранит администраторов, назначенных владельцами бота
+type AdminRepository interface {
+	// List возвращает всех назначенных администраторов в порядке назначения
+	List(ctx context.Context) ([]domain.Admin, error)
+	// Get возвращает назначенного администратора или ErrNotFound
+	Get(ctx context.Context, userID int64) (*domain.Admin, error)
+	// Add назначает администратора. Возвращает false, если пользователь уже администратор
+	Add(ctx context.Context, admin *domain.Admin) (bool, error)
+	// Delete отзывает права администратора. Возвращает false, если пользователь не был администратором
+	Delete(ctx context.Context, userID int64) (bool, error)
+}
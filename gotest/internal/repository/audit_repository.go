@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// AuditRepository хранит журнал аудита. Записи только добавляются
+type AuditRepository interface {
+	// Add добавляет запись и заполняет её ID
+	Add(ctx context.Context, entry *domain.AuditEntry) error
+	// ListBySubject возвращает записи о данных пользователя, начиная с новых
+	ListBySubject(ctx context.Context, subjectID int64) ([]domain.AuditEntry, error)
+}
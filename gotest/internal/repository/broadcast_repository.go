@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// BroadcastProgress — сколько получателей рассылки в каждом состоянии доставки
+type BroadcastProgress struct {
+	Total   int // Всего получателей
+	Pending int // Ещё не отправлено
+	Sent    int // Доставлено
+	Blocked int // Заблокировали бота
+	Failed  int // Не доставлено по другой причине
+	Quiet   int // Пропущено из-за тихих часов
+}
+
+// Done возвращает количество получателей, которым отправка уже выполнена
+func (p BroadcastProgress) Done() int {
+	return p.Sent + p.Blocked + p.Failed + p.Quiet
+}
+
+// add учитывает count получателей в состоянии status
+func (p *BroadcastProgress) add(status string, count int) {
+	p.Total += count
+	switch status {
+	case domain.DeliveryPending:
+		p.Pending += count
+	case domain.DeliverySent:
+		p.Sent += count
+	case domain.DeliveryBlocked:
+		p.Blocked += count
+	case domain.DeliveryFailed:
+		p.Failed += count
+	case domain.DeliveryQuiet:
+		p.Quiet += count
+	}
+}
+
+// BroadcastRepository хранит рассылки и результаты доставки каждому получателю
+type BroadcastRepository interface {
+	// Create сохраняет рассылку и заполняет её ID
+	Create(ctx context.Context, broadcast *domain.Broadcast) error
+	// AddRecipients добавляет чаты-получатели рассылки в состоянии DeliveryPending.
+	// Вместе с Create вызывается внутри Store.InTx, чтобы рассылка не осталась без получателей
+	AddRecipients(ctx context.Context, broadcastID int64, chatIDs []int64) error
+	// GetByID возвращает рассылку или ErrNotFound
+	GetByID(ctx context.Context, id int64) (*domain.Broadcast, error)
+	// ListRunning возвращает незавершённые рассылки, начиная со старых
+	ListRunning(ctx context.Context) ([]domain.Broadcast, error)
+	// SetProgressMessage запоминает сообщение, в котором показывается ход рассылки
+	SetProgressMessage(ctx context.Context, id, chatID int64, messageID int) error
+	// Finish переводит идущую рассылку в состояние status.
+	// Возвращает false, если рассылка уже завершена или её нет
+	Finish(ctx context.Context, id int64, status string, at time.Time) (bool, error)
+	// PendingRecipients возвращает до limit чатов-получателей, куда ещё не отправлено
+	PendingRecipients(ctx context.Context, broadcastID int64, limit int) ([]int64, error)
+	// SetDelivery записывает результат отправки в чат
+	SetDelivery(ctx context.Context, broadcastID, chatID int64, status, errText string, at time.Time) error
+	// Progress считает получателей рассылки по состояниям доставки
+	Progress(ctx context.Context, broadcastID int64) (BroadcastProgress, error)
+	// Deliveries возвращает результаты доставки всех рассылок в чат, начиная со старых рассылок
+	Deliveries(ctx context.Context, chatID int64) ([]domain.Delivery, error)
+	// DeleteRecipient удаляет пользователя из получателей всех рассылок (ID личного чата совпадает с ID пользователя)
+	DeleteRecipient(ctx context.Context, userID int64) error
+	// AnonymizeAuthor убирает автора userID из его рассылок (автор удалил свои данные)
+	AnonymizeAuthor(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// ChatFilter отбирает чаты для списков и рассылок
+type ChatFilter struct {
+	Types      []string // Типы чатов (пусто — любые)
+	ActiveOnly bool     // Только чаты, в которых бот сейчас состоит
+}
+
+// ChatRepository хранит группы и каналы, куда добавлен бот
+type ChatRepository interface {
+	// Save создаёт чат или обновляет его данные
+	Save(ctx context.Context, chat *domain.Chat) error
+	// GetByID возвращает чат или ErrNotFound
+	GetByID(ctx context.Context, id int64) (*domain.Chat, error)
+	// Migrate переносит чат на новый ID, когда группа становится супергруппой.
+	// Если чат с новым ID уже сохранён, старая запись удаляется
+	Migrate(ctx context.Context, fromID, toID int64) error
+	// Count возвращает количество чатов, подходящих под фильтр
+	Count(ctx context.Context, filter ChatFilter) (int, error)
+	// IDs возвращает ID чатов, подходящих под фильтр, по возрастанию
+	IDs(ctx context.Context, filter ChatFilter) ([]int64, error)
+	// List возвращает чаты, подходящие под фильтр, начиная с недавно добавленных
+	List(ctx context.Context, filter ChatFilter, offset, limit int) ([]domain.Chat, error)
+}
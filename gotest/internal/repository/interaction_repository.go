@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// InteractionFilter отбирает записи журнала взаимодействий.
+// Нулевые поля не ограничивают выборку
+type InteractionFilter struct {
+	UserID     int64     // Отправитель
+	ChatID     int64     // Чат
+	Command    string    // Команда без "/"
+	UpdateType string    // Тип обновления
+	Outcome    string    // Результат обработки
+	Since      time.Time // Не раньше (включительно)
+	Until      time.Time // Раньше (не включительно)
+}
+
+// CommandStat — сколько раз команду вызывали и сколько раз она завершилась ошибкой
+type CommandStat struct {
+	Command string // Команда без "/"
+	Count   int    // Количество вызовов
+	Errors  int    // Из них с ошибкой
+}
+
+// InteractionRepository хранит журнал взаимодействий
+type InteractionRepository interface {
+	// Add добавляет запись и заполняет её ID
+	Add(ctx context.Context, interaction *domain.Interaction) error
+	// Count возвращает количество записей, подходящих под фильтр
+	Count(ctx context.Context, filter InteractionFilter) (int, error)
+	// List возвращает записи, подходящие под фильтр, начиная с новых
+	List(ctx context.Context, filter InteractionFilter, offset, limit int) ([]domain.Interaction, error)
+	// TopCommands возвращает limit самых частых команд с since, начиная с самой частой
+	TopCommands(ctx context.Context, since time.Time, limit int) ([]CommandStat, error)
+	// DeleteBefore удаляет записи старше before и возвращает их количество
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	// DeleteByUser удаляет все записи пользователя
+	DeleteByUser(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryAdminRepository хранит назначенных администраторов в памяти
+type MemoryAdminRepository struct {
+	mu     sync.RWMutex
+	admins map[int64]domain.Admin // Карта: ID пользователя -> администратор
+}
+
+// NewMemoryAdminRepository создаёт пустой репозиторий администраторов в памяти
+func NewMemoryAdminRepository() *MemoryAdminRepository {
+	return &MemoryAdminRepository{
+		admins: make(map[int64]domain.Admin),
+	}
+}
+
+// List возвращает всех назначенных администраторов в порядке назначения
+func (r *MemoryAdminRepository) List(_ context.Context) ([]domain.Admin, error) {
+	r.mu.RLock()
+	admins := make([]domain.Admin, 0, len(r.admins))
+	for _, admin := range r.admins {
+		admins = append(admins, admin)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(admins, func(a, b domain.Admin) int {
+		if c := a.GrantedAt.Compare(b.GrantedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	return admins, nil
+}
+
+// Get возвращает назначенного администратора или ErrNotFound
+func (r *MemoryAdminRepository) Get(_ context.Context, userID int64) (*domain.Admin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	admin, exists := r.admins[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &admin, nil
+}
+
+// Add назначает администратора. Возвращает false, если пользователь уже администратор
+func (r *MemoryAdminRepository) Add(_ context.Context, admin *domain.Admin) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.admins[admin.UserID]; exists {
+		return false, nil
+	}
+	r.admins[admin.UserID] = *admin
+	return true, nil
+}
+
+// Delete отзывает права администратора. Возвращает false, если пользователь не был администратором
+func (r *MemoryAdminRepository) Delete(_ context.Context, userID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.admins[userID]
+	delete(r.admins, userID)
+	return exists, nil
+}
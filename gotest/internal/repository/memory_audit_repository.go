@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryAuditRepository хранит журнал аудита в памяти
+type MemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries []domain.AuditEntry
+}
+
+// NewMemoryAuditRepository создаёт пустой журнал в памяти
+func NewMemoryAuditRepository() *MemoryAuditRepository {
+	return &MemoryAuditRepository{}
+}
+
+// Add добавляет запись и заполняет её ID
+func (r *MemoryAuditRepository) Add(_ context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = int64(len(r.entries) + 1)
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+// ListBySubject возвращает записи о данных пользователя, начиная с новых
+func (r *MemoryAuditRepository) ListBySubject(_ context.Context, subjectID int64) ([]domain.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []domain.AuditEntry
+	for _, entry := range slices.Backward(r.entries) {
+		if entry.SubjectID == subjectID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// memoryRecipient — чат-получатель рассылки и результат доставки в него
+type memoryRecipient struct {
+	chatID int64
+	status string
+	errMsg string
+	sentAt time.Time
+}
+
+// MemoryBroadcastRepository хранит рассылки в памяти
+type MemoryBroadcastRepository struct {
+	mu         sync.RWMutex
+	nextID     int64
+	broadcasts map[int64]domain.Broadcast   // Карта: ID рассылки -> рассылка
+	recipients map[int64][]*memoryRecipient // Карта: ID рассылки -> получатели по возрастанию ID
+}
+
+// NewMemoryBroadcastRepository создаёт пустой репозиторий рассылок в памяти
+func NewMemoryBroadcastRepository() *MemoryBroadcastRepository {
+	return &MemoryBroadcastRepository{
+		broadcasts: make(map[int64]domain.Broadcast),
+		recipients: make(map[int64][]*memoryRecipient),
+	}
+}
+
+// Create сохраняет рассылку и заполняет её ID
+func (r *MemoryBroadcastRepository) Create(_ context.Context, broadcast *domain.Broadcast) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	broadcast.ID = r.nextID
+	r.broadcasts[broadcast.ID] = *broadcast
+	return nil
+}
+
+// AddRecipients добавляет чаты-получатели рассылки в состоянии DeliveryPending
+func (r *MemoryBroadcastRepository) AddRecipients(_ context.Context, broadcastID int64, chatIDs []int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipients := r.recipients[broadcastID]
+	for _, chatID := range chatIDs {
+		recipients = append(recipients, &memoryRecipient{chatID: chatID, status: domain.DeliveryPending})
+	}
+	slices.SortFunc(recipients, func(a, b *memoryRecipient) int {
+		return cmp.Compare(a.chatID, b.chatID)
+	})
+	r.recipients[broadcastID] = recipients
+	return nil
+}
+
+// GetByID возвращает рассылку или ErrNotFound
+func (r *MemoryBroadcastRepository) GetByID(_ context.Context, id int64) (*domain.Broadcast, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	broadcast, exists := r.broadcasts[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &broadcast, nil
+}
+
+// ListRunning возвращает незавершённые рассылки, начиная со старых
+func (r *MemoryBroadcastRepository) ListRunning(_ context.Context) ([]domain.Broadcast, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var broadcasts []domain.Broadcast
+	for _, broadcast := range r.broadcasts {
+		if !broadcast.IsFinished() {
+			broadcasts = append(broadcasts, broadcast)
+		}
+	}
+	slices.SortFunc(broadcasts, func(a, b domain.Broadcast) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return broadcasts, nil
+}
+
+// SetProgressMessage запоминает сообщение, в котором показывается ход рассылки
+func (r *MemoryBroadcastRepository) SetProgressMessage(_ context.Context, id, chatID int64, messageID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if broadcast, exists := r.broadcasts[id]; exists {
+		broadcast.ProgressChatID = chatID
+		broadcast.ProgressMessageID = messageID
+		r.broadcasts[id] = broadcast
+	}
+	return nil
+}
+
+// Finish переводит идущую рассылку в состояние status
+func (r *MemoryBroadcastRepository) Finish(_ context.Context, id int64, status string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	broadcast, exists := r.broadcasts[id]
+	if !exists || broadcast.IsFinished() {
+		return false, nil
+	}
+	broadcast.Status = status
+	broadcast.FinishedAt = at.UTC()
+	r.broadcasts[id] = broadcast
+	return true, nil
+}
+
+// PendingRecipients возвращает до limit чатов-получателей, куда ещё не отправлено
+func (r *MemoryBroadcastRepository) PendingRecipients(_ context.Context, broadcastID int64, limit int) ([]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var chatIDs []int64
+	for _, recipient := range r.recipients[broadcastID] {
+		if len(chatIDs) == limit {
+			break
+		}
+		if recipient.status == domain.DeliveryPending {
+			chatIDs = append(chatIDs, recipient.chatID)
+		}
+	}
+	return chatIDs, nil
+}
+
+// SetDelivery записывает результат отправки в чат
+func (r *MemoryBroadcastRepository) SetDelivery(_ context.Context, broadcastID, chatID int64, status, errText string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, recipient := range r.recipients[broadcastID] {
+		if recipient.chatID == chatID {
+			recipient.status = status
+			recipient.errMsg = errText
+			recipient.sentAt = at.UTC()
+			break
+		}
+	}
+	return nil
+}
+
+// Progress считает получателей рассылки по состояниям доставки
+func (r *MemoryBroadcastRepository) Progress(_ context.Context, broadcastID int64) (BroadcastProgress, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var progress BroadcastProgress
+	for _, recipient := range r.recipients[broadcastID] {
+		progress.add(recipient.status, 1)
+	}
+	return progress, nil
+}
+
+// Deliveries возвращает результаты доставки всех рассылок в чат, начиная со старых рассылок
+func (r *MemoryBroadcastRepository) Deliveries(_ context.Context, chatID int64) ([]domain.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []domain.Delivery
+	for broadcastID, recipients := range r.recipients {
+		for _, recipient := range recipients {
+			if recipient.chatID == chatID {
+				deliveries = append(deliveries, domain.Delivery{
+					BroadcastID: broadcastID,
+					ChatID:      recipient.chatID,
+					Status:      recipient.status,
+					Error:       recipient.errMsg,
+					SentAt:      recipient.sentAt,
+				})
+			}
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b domain.Delivery) int {
+		return cmp.Compare(a.BroadcastID, b.BroadcastID)
+	})
+	return deliveries, nil
+}
+
+// DeleteRecipient удаляет пользователя из получателей всех рассылок
+func (r *MemoryBroadcastRepository) DeleteRecipient(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, recipients := range r.recipients {
+		r.recipients[id] = slices.DeleteFunc(recipients, func(recipient *memoryRecipient) bool {
+			return recipient.chatID == userID
+		})
+	}
+	return nil
+}
+
+// AnonymizeAuthor убирает автора userID из его рассылок
+func (r *MemoryBroadcastRepository) AnonymizeAuthor(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, broadcast := range r.broadcasts {
+		if broadcast.AuthorID == userID {
+			broadcast.AuthorID = 0
+			r.broadcasts[id] = broadcast
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryChatRepository хранит чаты в памяти
+type MemoryChatRepository struct {
+	mu    sync.RWMutex
+	chats map[int64]domain.Chat
+}
+
+// NewMemoryChatRepository создаёт пустой репозиторий в памяти
+func NewMemoryChatRepository() *MemoryChatRepository {
+	return &MemoryChatRepository{
+		chats: make(map[int64]domain.Chat),
+	}
+}
+
+// Save создаёт чат или обновляет его данные
+func (r *MemoryChatRepository) Save(_ context.Context, chat *domain.Chat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.chats[chat.ID] = *chat
+	return nil
+}
+
+// GetByID возвращает чат по ID
+func (r *MemoryChatRepository) GetByID(_ context.Context, id int64) (*domain.Chat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chat, exists := r.chats[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &chat, nil
+}
+
+// Migrate переносит чат на новый ID
+func (r *MemoryChatRepository) Migrate(_ context.Context, fromID, toID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, exists := r.chats[fromID]
+	if !exists {
+		return nil
+	}
+	delete(r.chats, fromID)
+
+	if _, migrated := r.chats[toID]; !migrated {
+		chat.ID = toID
+		chat.Type = domain.ChatTypeSupergroup
+		chat.UpdatedAt = time.Now()
+		r.chats[toID] = chat
+	}
+	return nil
+}
+
+// Count возвращает количество чатов, подходящих под фильтр
+func (r *MemoryChatRepository) Count(_ context.Context, filter ChatFilter) (int, error) {
+	return len(r.filter(filter)), nil
+}
+
+// List возвращает чаты, подходящие под фильтр, начиная с недавно добавленных
+func (r *MemoryChatRepository) List(_ context.Context, filter ChatFilter, offset, limit int) ([]domain.Chat, error) {
+	chats := r.filter(filter)
+	slices.SortFunc(chats, func(a, b domain.Chat) int {
+		if order := b.JoinedAt.Compare(a.JoinedAt); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return page(chats, offset, limit), nil
+}
+
+// IDs возвращает ID чатов, подходящих под фильтр, по возрастанию
+func (r *MemoryChatRepository) IDs(_ context.Context, filter ChatFilter) ([]int64, error) {
+	chats := r.filter(filter)
+	ids := make([]int64, 0, len(chats))
+	for _, chat := range chats {
+		ids = append(ids, chat.ID)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// filter возвращает копии чатов, подходящих под фильтр
+func (r *MemoryChatRepository) filter(filter ChatFilter) []domain.Chat {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var chats []domain.Chat
+	for _, chat := range r.chats {
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, chat.Type) {
+			continue
+		}
+		if filter.ActiveOnly && !chat.IsActive() {
+			continue
+		}
+		chats = append(chats, chat)
+	}
+	return chats
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryInteractionRepository хранит журнал взаимодействий в памяти
+type MemoryInteractionRepository struct {
+	mu           sync.RWMutex
+	nextID       int64
+	interactions []domain.Interaction // В порядке добавления
+}
+
+// NewMemoryInteractionRepository создаёт пустой журнал в памяти
+func NewMemoryInteractionRepository() *MemoryInteractionRepository {
+	return &MemoryInteractionRepository{}
+}
+
+// Add добавляет запись и заполняет её ID
+func (r *MemoryInteractionRepository) Add(_ context.Context, interaction *domain.Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	interaction.ID = r.nextID
+	r.interactions = append(r.interactions, *interaction)
+	return nil
+}
+
+// Count возвращает количество записей, подходящих под фильтр
+func (r *MemoryInteractionRepository) Count(_ context.Context, filter InteractionFilter) (int, error) {
+	return len(r.filter(filter)), nil
+}
+
+// List возвращает записи, подходящие под фильтр, начиная с новых
+func (r *MemoryInteractionRepository) List(_ context.Context, filter InteractionFilter, offset, limit int) ([]domain.Interaction, error) {
+	interactions := r.filter(filter)
+	slices.SortStableFunc(interactions, func(a, b domain.Interaction) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return page(interactions, offset, limit), nil
+}
+
+// TopCommands возвращает limit самых частых команд с since
+func (r *MemoryInteractionRepository) TopCommands(_ context.Context, since time.Time, limit int) ([]CommandStat, error) {
+	byCommand := make(map[string]*CommandStat)
+	for _, interaction := range r.filter(InteractionFilter{Since: since}) {
+		if interaction.Command == "" {
+			continue
+		}
+
+		stat, exists := byCommand[interaction.Command]
+		if !exists {
+			stat = &CommandStat{Command: interaction.Command}
+			byCommand[interaction.Command] = stat
+		}
+		stat.Count++
+		if interaction.Outcome == domain.OutcomeError {
+			stat.Errors++
+		}
+	}
+
+	stats := make([]CommandStat, 0, len(byCommand))
+	for _, stat := range byCommand {
+		stats = append(stats, *stat)
+	}
+	slices.SortFunc(stats, func(a, b CommandStat) int {
+		if order := cmp.Compare(b.Count, a.Count); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.Command, b.Command)
+	})
+
+	return page(stats, 0, limit), nil
+}
+
+// DeleteBefore удаляет записи старше before
+func (r *MemoryInteractionRepository) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.interactions)
+	r.interactions = slices.DeleteFunc(r.interactions, func(interaction domain.Interaction) bool {
+		return interaction.CreatedAt.Before(before)
+	})
+	return int64(count - len(r.interactions)), nil
+}
+
+// DeleteByUser удаляет все записи пользователя
+func (r *MemoryInteractionRepository) DeleteByUser(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = slices.DeleteFunc(r.interactions, func(interaction domain.Interaction) bool {
+		return interaction.UserID == userID
+	})
+	return nil
+}
+
+// filter возвращает записи, подходящие под фильтр, от новых к старым
+func (r *MemoryInteractionRepository) filter(filter InteractionFilter) []domain.Interaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var interactions []domain.Interaction
+	for _, interaction := range slices.Backward(r.interactions) {
+		switch {
+		case filter.UserID != 0 && interaction.UserID != filter.UserID,
+			filter.ChatID != 0 && interaction.ChatID != filter.ChatID,
+			filter.Command != "" && interaction.Command != filter.Command,
+			filter.UpdateType != "" && interaction.UpdateType != filter.UpdateType,
+			filter.Outcome != "" && interaction.Outcome != filter.Outcome,
+			!filter.Since.IsZero() && interaction.CreatedAt.Before(filter.Since),
+			!filter.Until.IsZero() && !interaction.CreatedAt.Before(filter.Until):
+			continue
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryRestrictionRepository хранит ограничения пользователей в памяти
+type MemoryRestrictionRepository struct {
+	mu           sync.RWMutex
+	restrictions map[int64]domain.Restriction // Карта: ID пользователя -> ограничение
+}
+
+// NewMemoryRestrictionRepository создаёт пустой репозиторий ограничений в памяти
+func NewMemoryRestrictionRepository() *MemoryRestrictionRepository {
+	return &MemoryRestrictionRepository{
+		restrictions: make(map[int64]domain.Restriction),
+	}
+}
+
+// Get возвращает ограничение пользователя или ErrNotFound
+func (r *MemoryRestrictionRepository) Get(_ context.Context, userID int64) (*domain.Restriction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	restriction, exists := r.restrictions[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &restriction, nil
+}
+
+// Save накладывает ограничение, заменяя прежнее ограничение пользователя
+func (r *MemoryRestrictionRepository) Save(_ context.Context, restriction *domain.Restriction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.restrictions[restriction.UserID] = *restriction
+	return nil
+}
+
+// Delete снимает ограничение. Возвращает false, если ограничения не было
+func (r *MemoryRestrictionRepository) Delete(_ context.Context, userID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.restrictions[userID]
+	delete(r.restrictions, userID)
+	return exists, nil
+}
+
+// DeleteExpired удаляет ограничения, истёкшие к моменту now
+func (r *MemoryRestrictionRepository) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for userID, restriction := range r.restrictions {
+		if !restriction.IsActiveAt(now) {
+			delete(r.restrictions, userID)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ListActive возвращает ограничения, действующие в момент now, начиная с новых
+func (r *MemoryRestrictionRepository) ListActive(_ context.Context, now time.Time) ([]domain.Restriction, error) {
+	r.mu.RLock()
+	var restrictions []domain.Restriction
+	for _, restriction := range r.restrictions {
+		if restriction.IsActiveAt(now) {
+			restrictions = append(restrictions, restriction)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(restrictions, func(a, b domain.Restriction) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	return restrictions, nil
+}
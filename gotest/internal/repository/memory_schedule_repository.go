@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryScheduleRepository хранит рассылки по расписанию в памяти
+type MemoryScheduleRepository struct {
+	mu        sync.RWMutex
+	nextID    int64
+	schedules map[int64]domain.ScheduledBroadcast // Карта: ID -> запланированная рассылка
+}
+
+// NewMemoryScheduleRepository создаёт пустой репозиторий рассылок по расписанию в памяти
+func NewMemoryScheduleRepository() *MemoryScheduleRepository {
+	return &MemoryScheduleRepository{
+		schedules: make(map[int64]domain.ScheduledBroadcast),
+	}
+}
+
+// Create сохраняет запланированную рассылку и заполняет её ID
+func (r *MemoryScheduleRepository) Create(_ context.Context, schedule *domain.ScheduledBroadcast) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	schedule.ID = r.nextID
+	r.schedules[schedule.ID] = cloneSchedule(*schedule)
+	return nil
+}
+
+// GetByID возвращает запланированную рассылку или ErrNotFound
+func (r *MemoryScheduleRepository) GetByID(_ context.Context, id int64) (*domain.ScheduledBroadcast, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, exists := r.schedules[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	schedule = cloneSchedule(schedule)
+	return &schedule, nil
+}
+
+// Update сохраняет изменения запланированной рассылки
+func (r *MemoryScheduleRepository) Update(_ context.Context, schedule *domain.ScheduledBroadcast) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.schedules[schedule.ID]
+	if !exists {
+		return nil
+	}
+
+	// Автор и дата создания не меняются, как и в SQL-репозитории
+	updated := cloneSchedule(*schedule)
+	updated.AuthorID = stored.AuthorID
+	updated.CreatedAt = stored.CreatedAt
+	r.schedules[schedule.ID] = updated
+	return nil
+}
+
+// ListActive возвращает рассылки, которые ещё будут отправлены, начиная с ближайших
+func (r *MemoryScheduleRepository) ListActive(_ context.Context) ([]domain.ScheduledBroadcast, error) {
+	return r.filter(func(schedule domain.ScheduledBroadcast) bool {
+		return schedule.IsActive()
+	}), nil
+}
+
+// Due возвращает рассылки, время отправки которых наступило к моменту now, начиная с ранних
+func (r *MemoryScheduleRepository) Due(_ context.Context, now time.Time) ([]domain.ScheduledBroadcast, error) {
+	return r.filter(func(schedule domain.ScheduledBroadcast) bool {
+		return schedule.IsActive() && !schedule.NextRunAt.After(now)
+	}), nil
+}
+
+// AnonymizeAuthor убирает автора userID из его рассылок и отменяет те, что ещё не отправлены
+func (r *MemoryScheduleRepository) AnonymizeAuthor(_ context.Context, userID int64, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, schedule := range r.schedules {
+		if schedule.AuthorID != userID {
+			continue
+		}
+		schedule.AuthorID = 0
+		if schedule.IsActive() {
+			schedule.Status = domain.ScheduleCancelled
+			schedule.UpdatedAt = now.UTC()
+		}
+		r.schedules[id] = schedule
+	}
+	return nil
+}
+
+// filter возвращает подходящие рассылки по возрастанию времени отправки
+func (r *MemoryScheduleRepository) filter(match func(schedule domain.ScheduledBroadcast) bool) []domain.ScheduledBroadcast {
+	r.mu.RLock()
+	var schedules []domain.ScheduledBroadcast
+	for _, schedule := range r.schedules {
+		if match(schedule) {
+			schedules = append(schedules, cloneSchedule(schedule))
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(schedules, func(a, b domain.ScheduledBroadcast) int {
+		if c := a.NextRunAt.Compare(b.NextRunAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return schedules
+}
+
+// cloneSchedule копирует рассылку вместе со списком типов чатов аудитории
+func cloneSchedule(schedule domain.ScheduledBroadcast) domain.ScheduledBroadcast {
+	schedule.Audience.ChatTypes = slices.Clone(schedule.Audience.ChatTypes)
+	return schedule
+}
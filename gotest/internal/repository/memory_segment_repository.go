@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemorySegmentRepository хранит сегменты в памяти
+type MemorySegmentRepository struct {
+	mu       sync.RWMutex
+	segments map[string]domain.Segment // Карта: имя -> сегмент
+}
+
+// NewMemorySegmentRepository создаёт пустой репозиторий сегментов в памяти
+func NewMemorySegmentRepository() *MemorySegmentRepository {
+	return &MemorySegmentRepository{
+		segments: make(map[string]domain.Segment),
+	}
+}
+
+// Get возвращает сегмент по имени или ErrNotFound
+func (r *MemorySegmentRepository) Get(_ context.Context, name string) (*domain.Segment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segment, exists := r.segments[name]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &segment, nil
+}
+
+// Save создаёт сегмент или перезаписывает сегмент с тем же именем
+func (r *MemorySegmentRepository) Save(_ context.Context, segment *domain.Segment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *segment
+	stored.Audience.ChatTypes = slices.Clone(segment.Audience.ChatTypes)
+	r.segments[segment.Name] = stored
+	return nil
+}
+
+// List возвращает все сегменты по алфавиту
+func (r *MemorySegmentRepository) List(_ context.Context) ([]domain.Segment, error) {
+	r.mu.RLock()
+	segments := make([]domain.Segment, 0, len(r.segments))
+	for _, segment := range r.segments {
+		segments = append(segments, segment)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(segments, func(a, b domain.Segment) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return segments, nil
+}
+
+// Delete удаляет сегмент. Возвращает false, если сегмента не было
+func (r *MemorySegmentRepository) Delete(_ context.Context, name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.segments[name]
+	delete(r.segments, name)
+	return exists, nil
+}
+
+// AnonymizeAuthor убирает автора userID из сохранённых им сегментов
+func (r *MemorySegmentRepository) AnonymizeAuthor(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, segment := range r.segments {
+		if segment.CreatedBy == userID {
+			segment.CreatedBy = 0
+			r.segments[name] = segment
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemorySettingsRepository хранит настройки в памяти
+type MemorySettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[int64]domain.UserSettings
+}
+
+// NewMemorySettingsRepository создаёт пустой репозиторий настроек в памяти
+func NewMemorySettingsRepository() *MemorySettingsRepository {
+	return &MemorySettingsRepository{
+		settings: make(map[int64]domain.UserSettings),
+	}
+}
+
+// Get возвращает настройки пользователя
+func (r *MemorySettingsRepository) Get(_ context.Context, userID int64) (*domain.UserSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, exists := r.settings[userID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &settings, nil
+}
+
+// Save создаёт или перезаписывает настройки пользователя
+func (r *MemorySettingsRepository) Save(_ context.Context, settings *domain.UserSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings[settings.UserID] = *settings
+	return nil
+}
+
+// Delete удаляет настройки пользователя
+func (r *MemorySettingsRepository) Delete(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.settings, userID)
+	return nil
+}
+
+// lookup возвращает настройки пользователя; у nil-репозитория настроек нет
+func (r *MemorySettingsRepository) lookup(userID int64) (domain.UserSettings, bool) {
+	if r == nil {
+		return domain.UserSettings{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, exists := r.settings[userID]
+	return settings, exists
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// MemoryUserRepository хранит пользователей в памяти.
+// Используется в тестах и когда база данных отключена
+type MemoryUserRepository struct {
+	mu       sync.RWMutex
+	users    map[int64]domain.User
+	settings *MemorySettingsRepository // Настройки для фильтров по языку и уведомлениям (может быть nil)
+}
+
+// NewMemoryUserRepository создаёт пустой репозиторий в памяти.
+// settings нужны ReachableIDs, чтобы отбирать пользователей по языку и уведомлениям
+func NewMemoryUserRepository(settings *MemorySettingsRepository) *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users:    make(map[int64]domain.User),
+		settings: settings,
+	}
+}
+
+// Upsert создаёт пользователя или обновляет его данные
+func (r *MemoryUserRepository) Upsert(_ context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *user
+	if existing, exists := r.users[user.ID]; exists {
+		stored.FirstSeenAt = existing.FirstSeenAt
+	}
+	r.users[user.ID] = stored
+	return nil
+}
+
+// GetByID возвращает пользователя по ID
+func (r *MemoryUserRepository) GetByID(_ context.Context, id int64) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+// SetBlocked отмечает, что пользователь заблокировал бота
+func (r *MemoryUserRepository) SetBlocked(_ context.Context, id int64, blocked bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, exists := r.users[id]; exists {
+		user.IsBlocked = blocked
+		r.users[id] = user
+	}
+	return nil
+}
+
+// Count возвращает количество пользователей
+func (r *MemoryUserRepository) Count(_ context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.users), nil
+}
+
+// CountNew возвращает количество пользователей, впервые написавших боту не раньше since
+func (r *MemoryUserRepository) CountNew(_ context.Context, since time.Time) (int, error) {
+	return r.count(func(user domain.User) bool {
+		return !user.FirstSeenAt.Before(since)
+	}), nil
+}
+
+// CountActive возвращает количество пользователей, обращавшихся к боту не раньше since
+func (r *MemoryUserRepository) CountActive(_ context.Context, since time.Time) (int, error) {
+	return r.count(func(user domain.User) bool {
+		return !user.LastSeenAt.Before(since)
+	}), nil
+}
+
+// CountBlocked возвращает количество пользователей, заблокировавших бота
+func (r *MemoryUserRepository) CountBlocked(_ context.Context) (int, error) {
+	return r.count(func(user domain.User) bool {
+		return user.IsBlocked
+	}), nil
+}
+
+// count считает пользователей, для которых match возвращает true
+func (r *MemoryUserRepository) count(match func(user domain.User) bool) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, user := range r.users {
+		if match(user) {
+			count++
+		}
+	}
+	return count
+}
+
+// ReachableIDs возвращает ID пользователей, не заблокировавших бота
+// и подходящих под фильтр, по возрастанию
+func (r *MemoryUserRepository) ReachableIDs(_ context.Context, filter UserFilter) ([]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []int64
+	for _, user := range r.users {
+		settings, exists := r.settings.lookup(user.ID)
+
+		language := settings.Language
+		if language == "" {
+			language = strings.ToLower(user.LanguageCode)
+		}
+		notifications := !exists || settings.NotificationsEnabled
+
+		switch {
+		case user.IsBlocked,
+			filter.Language != "" && language != filter.Language && !strings.HasPrefix(language, filter.Language+"-"),
+			!filter.ActiveSince.IsZero() && user.LastSeenAt.Before(filter.ActiveSince),
+			!filter.JoinedSince.IsZero() && user.FirstSeenAt.Before(filter.JoinedSince),
+			filter.Notifications != nil && notifications != *filter.Notifications:
+			continue
+		}
+		ids = append(ids, user.ID)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// List возвращает пользователей, начиная с недавно активных
+func (r *MemoryUserRepository) List(_ context.Context, offset, limit int) ([]domain.User, error) {
+	r.mu.RLock()
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(users, func(a, b domain.User) int {
+		if order := b.LastSeenAt.Compare(a.LastSeenAt); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return page(users, offset, limit), nil
+}
+
+// Delete удаляет пользователя
+func (r *MemoryUserRepository) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, id)
+	return nil
+}
+
+// page вырезает из среза страницу [offset, offset+limit)
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
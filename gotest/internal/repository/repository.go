@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNotFound возвращается, если запись не найдена
+var ErrNotFound = errors.New("запись не найдена")
+
+// Querier — то общее, что есть у *sql.DB и *sql.Tx.
+// Репозитории принимают Querier, поэтому работают и внутри транзакции
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// RestrictionRepository хранит ограничения пользователей (блокировки и запреты писать боту)
+type RestrictionRepository interface {
+	// Get возвращает ограничение пользователя или ErrNotFound (в том числе истёкшее)
+	Get(ctx context.Context, userID int64) (*domain.Restriction, error)
+	// Save накладывает ограничение, заменяя прежнее ограничение пользователя
+	Save(ctx context.Context, restriction *domain.Restriction) error
+	// Delete снимает ограничение. Возвращает false, если ограничения не было
+	Delete(ctx context.Context, userID int64) (bool, error)
+	// DeleteExpired удаляет ограничения, истёкшие к моменту now, и возвращает их количество
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	// ListActive возвращает ограничения, действующие в момент now, начиная с новых
+	ListActive(ctx context.Context, now time.Time) ([]domain.Restriction, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// ScheduleRepository хранит рассылки по расписанию
+type ScheduleRepository interface {
+	// Create сохраняет запланированную рассылку и заполняет её ID
+	Create(ctx context.Context, schedule *domain.ScheduledBroadcast) error
+	// GetByID возвращает запланированную рассылку или ErrNotFound
+	GetByID(ctx context.Context, id int64) (*domain.ScheduledBroadcast, error)
+	// Update сохраняет изменения запланированной рассылки (все поля, кроме ID, автора и даты создания)
+	Update(ctx context.Context, schedule *domain.ScheduledBroadcast) error
+	// ListActive возвращает рассылки, которые ещё будут отправлены, начиная с ближайших
+	ListActive(ctx context.Context) ([]domain.ScheduledBroadcast, error)
+	// Due возвращает рассылки, время отправки которых наступило к моменту now, начиная с ранних
+	Due(ctx context.Context, now time.Time) ([]domain.ScheduledBroadcast, error)
+	// AnonymizeAuthor убирает автора userID из его рассылок (автор удалил свои данные).
+	// Ещё не отправленные рассылки отменяются: их исходные сообщения лежат в чате автора
+	AnonymizeAuthor(ctx context.Context, userID int64, now time.Time) error
+}
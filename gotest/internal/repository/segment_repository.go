@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// SegmentRepository хранит сохранённые аудитории рассылок
+type SegmentRepository interface {
+	// Get возвращает сегмент по имени или ErrNotFound
+	Get(ctx context.Context, name string) (*domain.Segment, error)
+	// Save создаёт сегмент или перезаписывает сегмент с тем же именем
+	Save(ctx context.Context, segment *domain.Segment) error
+	// List возвращает все сегменты по алфавиту
+	List(ctx context.Context) ([]domain.Segment, error)
+	// Delete удаляет сегмент. Возвращает false, если сегмента не было
+	Delete(ctx context.Context, name string) (bool, error)
+	// AnonymizeAuthor убирает автора userID из сохранённых им сегментов (автор удалил свои данные)
+	AnonymizeAuthor(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// SettingsRepository хранит настройки пользователей
+type SettingsRepository interface {
+	// Get возвращает настройки пользователя или ErrNotFound, если он их не менял
+	Get(ctx context.Context, userID int64) (*domain.UserSettings, error)
+	// Save создаёт или перезаписывает настройки пользователя
+	Save(ctx context.Context, settings *domain.UserSettings) error
+	// Delete удаляет настройки пользователя
+	Delete(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLAdminRepository хранит администраторов в таблице admins (PostgreSQL или SQLite)
+type SQLAdminRepository struct {
+	db Querier
+}
+
+// NewSQLAdminRepository создаёт новый репозиторий администраторов
+func NewSQLAdminRepository(db Querier) *SQLAdminRepository {
+	return &SQLAdminRepository{db: db}
+}
+
+// List возвращает всех назначенных администраторов в порядке назначения
+func (r *SQLAdminRepository) List(ctx context.Context) ([]domain.Admin, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT user_id, granted_by, granted_at FROM admins ORDER BY granted_at, user_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []domain.Admin
+	for rows.Next() {
+		var admin domain.Admin
+		if err := rows.Scan(&admin.UserID, &admin.GrantedBy, &admin.GrantedAt); err != nil {
+			return nil, err
+		}
+		admins = append(admins, admin)
+	}
+	return admins, rows.Err()
+}
+
+// Get возвращает назначенного администратора или ErrNotFound
+func (r *SQLAdminRepository) Get(ctx context.Context, userID int64) (*domain.Admin, error) {
+	var admin domain.Admin
+	err := r.db.QueryRowContext(ctx, `SELECT user_id, granted_by, granted_at FROM admins WHERE user_id = $1`, userID).
+		Scan(&admin.UserID, &admin.GrantedBy, &admin.GrantedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+// Add назначает администратора. Возвращает false, если пользователь уже администратор
+func (r *SQLAdminRepository) Add(ctx context.Context, admin *domain.Admin) (bool, error) {
+	query := `
+		INSERT INTO admins (user_id, granted_by, granted_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, admin.UserID, admin.GrantedBy, admin.GrantedAt.UTC())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Delete отзывает права администратора. Возвращает false, если пользователь не был администратором
+func (r *SQLAdminRepository) Delete(ctx context.Context, userID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM admins WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
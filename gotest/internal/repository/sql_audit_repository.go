@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLAuditRepository хранит журнал аудита в таблице audit_log (PostgreSQL или SQLite)
+type SQLAuditRepository struct {
+	db Querier
+}
+
+// NewSQLAuditRepository создаёт новый репозиторий журнала аудита
+func NewSQLAuditRepository(db Querier) *SQLAuditRepository {
+	return &SQLAuditRepository{db: db}
+}
+
+// Add добавляет запись и заполняет её ID
+func (r *SQLAuditRepository) Add(ctx context.Context, entry *domain.AuditEntry) error {
+	// RETURNING поддерживают и PostgreSQL, и SQLite (начиная с 3.35)
+	query := `
+		INSERT INTO audit_log (action, actor_id, subject_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	return r.db.QueryRowContext(ctx, query,
+		entry.Action,
+		entry.ActorID,
+		entry.SubjectID,
+		entry.Details,
+		entry.CreatedAt.UTC(),
+	).Scan(&entry.ID)
+}
+
+// ListBySubject возвращает записи о данных пользователя, начиная с новых
+func (r *SQLAuditRepository) ListBySubject(ctx context.Context, subjectID int64) ([]domain.AuditEntry, error) {
+	query := `
+		SELECT id, action, actor_id, subject_id, details, created_at
+		FROM audit_log
+		WHERE subject_id = $1
+		ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var entry domain.AuditEntry
+		err := rows.Scan(&entry.ID, &entry.Action, &entry.ActorID, &entry.SubjectID, &entry.Details, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// recipientsBatchSize — по скольку получателей добавлять одним запросом
+const recipientsBatchSize = 500
+
+// SQLBroadcastRepository хранит рассылки в таблицах broadcasts и broadcast_recipients (PostgreSQL или SQLite)
+type SQLBroadcastRepository struct {
+	db Querier
+}
+
+// NewSQLBroadcastRepository создаёт новый репозиторий рассылок
+func NewSQLBroadcastRepository(db Querier) *SQLBroadcastRepository {
+	return &SQLBroadcastRepository{db: db}
+}
+
+// broadcastColumns — столбцы таблицы broadcasts (кроме id) в порядке scanBroadcast
+const broadcastColumns = `author_id, source_chat_id, source_message_id, forward, audience, status,
+	progress_chat_id, progress_message_id, created_at, finished_at`
+
+// Create сохраняет рассылку и заполняет её ID
+func (r *SQLBroadcastRepository) Create(ctx context.Context, broadcast *domain.Broadcast) error {
+	audience, err := json.Marshal(broadcast.Audience)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO broadcasts (` + broadcastColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	return r.db.QueryRowContext(ctx, query,
+		broadcast.AuthorID,
+		broadcast.SourceChatID,
+		broadcast.SourceMessageID,
+		broadcast.Forward,
+		string(audience),
+		broadcast.Status,
+		broadcast.ProgressChatID,
+		broadcast.ProgressMessageID,
+		broadcast.CreatedAt.UTC(),
+		nullTime(broadcast.FinishedAt),
+	).Scan(&broadcast.ID)
+}
+
+// AddRecipients добавляет чаты-получатели рассылки в состоянии DeliveryPending
+func (r *SQLBroadcastRepository) AddRecipients(ctx context.Context, broadcastID int64, chatIDs []int64) error {
+	for start := 0; start < len(chatIDs); start += recipientsBatchSize {
+		batch := chatIDs[start:min(start+recipientsBatchSize, len(chatIDs))]
+
+		// Один INSERT на пачку: VALUES ($1, $2, 'pending'), ($1, $3, 'pending')...
+		values := make([]string, len(batch))
+		args := []any{broadcastID}
+		for i, chatID := range batch {
+			args = append(args, chatID)
+			values[i] = fmt.Sprintf("($1, $%d, '%s')", len(args), domain.DeliveryPending)
+		}
+
+		query := `INSERT INTO broadcast_recipients (broadcast_id, chat_id, status) VALUES ` + strings.Join(values, ", ")
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID возвращает рассылку или ErrNotFound
+func (r *SQLBroadcastRepository) GetByID(ctx context.Context, id int64) (*domain.Broadcast, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, `+broadcastColumns+` FROM broadcasts WHERE id = $1`, id)
+
+	broadcast, err := scanBroadcast(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+// ListRunning возвращает незавершённые рассылки, начиная со старых
+func (r *SQLBroadcastRepository) ListRunning(ctx context.Context) ([]domain.Broadcast, error) {
+	query := `SELECT id, ` + broadcastColumns + ` FROM broadcasts WHERE status = $1 ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.BroadcastRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var broadcasts []domain.Broadcast
+	for rows.Next() {
+		broadcast, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, err
+		}
+		broadcasts = append(broadcasts, broadcast)
+	}
+	return broadcasts, rows.Err()
+}
+
+// SetProgressMessage запоминает сообщение, в котором показывается ход рассылки
+func (r *SQLBroadcastRepository) SetProgressMessage(ctx context.Context, id, chatID int64, messageID int) error {
+	query := `UPDATE broadcasts SET progress_chat_id = $1, progress_message_id = $2 WHERE id = $3`
+	_, err := r.db.ExecContext(ctx, query, chatID, messageID, id)
+	return err
+}
+
+// Finish переводит идущую рассылку в состояние status
+func (r *SQLBroadcastRepository) Finish(ctx context.Context, id int64, status string, at time.Time) (bool, error) {
+	// Условие на status не даёт отмене и завершению перезаписать друг друга
+	query := `UPDATE broadcasts SET status = $1, finished_at = $2 WHERE id = $3 AND status = $4`
+
+	result, err := r.db.ExecContext(ctx, query, status, at.UTC(), id, domain.BroadcastRunning)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// PendingRecipients возвращает до limit чатов-получателей, куда ещё не отправлено
+func (r *SQLBroadcastRepository) PendingRecipients(ctx context.Context, broadcastID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT chat_id
+		FROM broadcast_recipients
+		WHERE broadcast_id = $1 AND status = $2
+		ORDER BY chat_id
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, broadcastID, domain.DeliveryPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chatIDs []int64
+	for rows.Next() {
+		var chatID int64
+		if err := rows.Scan(&chatID); err != nil {
+			return nil, err
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+	return chatIDs, rows.Err()
+}
+
+// SetDelivery записывает результат отправки в чат
+func (r *SQLBroadcastRepository) SetDelivery(ctx context.Context, broadcastID, chatID int64, status, errText string, at time.Time) error {
+	query := `
+		UPDATE broadcast_recipients
+		SET status = $1, error = $2, sent_at = $3
+		WHERE broadcast_id = $4 AND chat_id = $5`
+
+	_, err := r.db.ExecContext(ctx, query, status, errText, at.UTC(), broadcastID, chatID)
+	return err
+}
+
+// Progress считает получателей рассылки по состояниям доставки
+func (r *SQLBroadcastRepository) Progress(ctx context.Context, broadcastID int64) (BroadcastProgress, error) {
+	query := `SELECT status, COUNT(*) FROM broadcast_recipients WHERE broadcast_id = $1 GROUP BY status`
+
+	rows, err := r.db.QueryContext(ctx, query, broadcastID)
+	if err != nil {
+		return BroadcastProgress{}, err
+	}
+	defer rows.Close()
+
+	var progress BroadcastProgress
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return BroadcastProgress{}, err
+		}
+		progress.add(status, count)
+	}
+	return progress, rows.Err()
+}
+
+// Deliveries возвращает результаты доставки всех рассылок в чат, начиная со старых рассылок
+func (r *SQLBroadcastRepository) Deliveries(ctx context.Context, chatID int64) ([]domain.Delivery, error) {
+	query := `
+		SELECT broadcast_id, chat_id, status, error, sent_at
+		FROM broadcast_recipients
+		WHERE chat_id = $1
+		ORDER BY broadcast_id`
+
+	rows, err := r.db.QueryContext(ctx, query, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []domain.Delivery
+	for rows.Next() {
+		var (
+			delivery domain.Delivery
+			sentAt   sql.NullTime
+		)
+		if err := rows.Scan(&delivery.BroadcastID, &delivery.ChatID, &delivery.Status, &delivery.Error, &sentAt); err != nil {
+			return nil, err
+		}
+		if sentAt.Valid {
+			delivery.SentAt = sentAt.Time.UTC()
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// DeleteRecipient удаляет пользователя из получателей всех рассылок
+func (r *SQLBroadcastRepository) DeleteRecipient(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM broadcast_recipients WHERE chat_id = $1`, userID)
+	return err
+}
+
+// AnonymizeAuthor убирает автора userID из его рассылок
+func (r *SQLBroadcastRepository) AnonymizeAuthor(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE broadcasts SET author_id = 0 WHERE author_id = $1`, userID)
+	return err
+}
+
+// scanBroadcast читает рассылку из строки результата (id и столбцы broadcastColumns)
+func scanBroadcast(row rowScanner) (domain.Broadcast, error) {
+	var (
+		broadcast  domain.Broadcast
+		audience   string
+		finishedAt sql.NullTime
+	)
+	err := row.Scan(
+		&broadcast.ID,
+		&broadcast.AuthorID,
+		&broadcast.SourceChatID,
+		&broadcast.SourceMessageID,
+		&broadcast.Forward,
+		&audience,
+		&broadcast.Status,
+		&broadcast.ProgressChatID,
+		&broadcast.ProgressMessageID,
+		&broadcast.CreatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return broadcast, err
+	}
+
+	broadcast.FinishedAt = finishedAt.Time
+	// Рассылки, созданные до появления сегментов, уходили всем пользователям
+	if audience != "" {
+		err = json.Unmarshal([]byte(audience), &broadcast.Audience)
+	}
+	return broadcast, err
+}
+
+// nullTime превращает нулевое время в NULL
+func nullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
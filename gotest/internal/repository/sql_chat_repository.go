@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLChatRepository хранит чаты в таблице chats (PostgreSQL или SQLite)
+type SQLChatRepository struct {
+	db Querier
+}
+
+// NewSQLChatRepository создаёт новый репозиторий чатов
+func NewSQLChatRepository(db Querier) *SQLChatRepository {
+	return &SQLChatRepository{db: db}
+}
+
+// chatColumns — столбцы таблицы chats в порядке scanChat
+const chatColumns = `id, type, title, username, bot_status, can_send_messages, can_delete_messages,
+	can_restrict_members, can_pin_messages, can_invite_users, joined_at, left_at, updated_at`
+
+// Save создаёт чат или обновляет его данные
+func (r *SQLChatRepository) Save(ctx context.Context, chat *domain.Chat) error {
+	query := `
+		INSERT INTO chats (` + chatColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			type = EXCLUDED.type,
+			title = EXCLUDED.title,
+			username = EXCLUDED.username,
+			bot_status = EXCLUDED.bot_status,
+			can_send_messages = EXCLUDED.can_send_messages,
+			can_delete_messages = EXCLUDED.can_delete_messages,
+			can_restrict_members = EXCLUDED.can_restrict_members,
+			can_pin_messages = EXCLUDED.can_pin_messages,
+			can_invite_users = EXCLUDED.can_invite_users,
+			joined_at = EXCLUDED.joined_at,
+			left_at = EXCLUDED.left_at,
+			updated_at = EXCLUDED.updated_at`
+
+	var leftAt sql.NullTime
+	if !chat.LeftAt.IsZero() {
+		leftAt = sql.NullTime{Time: chat.LeftAt.UTC(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		chat.ID,
+		chat.Type,
+		chat.Title,
+		chat.Username,
+		chat.BotStatus,
+		chat.CanSendMessages,
+		chat.CanDeleteMessages,
+		chat.CanRestrictMembers,
+		chat.CanPinMessages,
+		chat.CanInviteUsers,
+		chat.JoinedAt.UTC(),
+		leftAt,
+		chat.UpdatedAt.UTC(),
+	)
+	return err
+}
+
+// GetByID возвращает чат по ID
+func (r *SQLChatRepository) GetByID(ctx context.Context, id int64) (*domain.Chat, error) {
+	query := `SELECT ` + chatColumns + ` FROM chats WHERE id = $1`
+
+	chat, err := scanChat(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+// Migrate переносит чат на новый ID
+func (r *SQLChatRepository) Migrate(ctx context.Context, fromID, toID int64) error {
+	// Оба запроса безопасно повторять: сообщения migrate_to_chat_id и
+	// migrate_from_chat_id приходят в оба чата, и Migrate вызывается дважды
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM chats WHERE id = $1 AND EXISTS (SELECT 1 FROM chats WHERE id = $2)`,
+		fromID, toID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE chats SET id = $1, type = $2, updated_at = $3 WHERE id = $4`,
+		toID, domain.ChatTypeSupergroup, time.Now().UTC(), fromID,
+	)
+	return err
+}
+
+// Count возвращает количество чатов, подходящих под фильтр
+func (r *SQLChatRepository) Count(ctx context.Context, filter ChatFilter) (int, error) {
+	where, args := chatWhere(filter)
+
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM chats`+where, args...).Scan(&count)
+	return count, err
+}
+
+// List возвращает чаты, подходящие под фильтр, начиная с недавно добавленных
+func (r *SQLChatRepository) List(ctx context.Context, filter ChatFilter, offset, limit int) ([]domain.Chat, error) {
+	where, args := chatWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM chats%s
+		ORDER BY joined_at DESC, id
+		LIMIT $%d OFFSET $%d`, chatColumns, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chats []domain.Chat
+	for rows.Next() {
+		chat, err := scanChat(rows)
+		if err != nil {
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	return chats, rows.Err()
+}
+
+// IDs возвращает ID чатов, подходящих под фильтр, по возрастанию
+func (r *SQLChatRepository) IDs(ctx context.Context, filter ChatFilter) ([]int64, error) {
+	where, args := chatWhere(filter)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM chats`+where+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// chatWhere формирует условие WHERE для фильтра и его параметры
+func chatWhere(filter ChatFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if len(filter.Types) > 0 {
+		placeholders := make([]string, len(filter.Types))
+		for i, chatType := range filter.Types {
+			args = append(args, chatType)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "type IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.ActiveOnly {
+		conditions = append(conditions, "bot_status IN ('creator', 'administrator', 'member', 'restricted')")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// scanChat читает чат из строки результата (столбцы chatColumns)
+func scanChat(row rowScanner) (domain.Chat, error) {
+	var (
+		chat   domain.Chat
+		leftAt sql.NullTime
+	)
+	err := row.Scan(
+		&chat.ID,
+		&chat.Type,
+		&chat.Title,
+		&chat.Username,
+		&chat.BotStatus,
+		&chat.CanSendMessages,
+		&chat.CanDeleteMessages,
+		&chat.CanRestrictMembers,
+		&chat.CanPinMessages,
+		&chat.CanInviteUsers,
+		&chat.JoinedAt,
+		&leftAt,
+		&chat.UpdatedAt,
+	)
+	chat.LeftAt = leftAt.Time
+	return chat, err
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLInteractionRepository хранит журнал взаимодействий в таблице interactions (PostgreSQL или SQLite)
+type SQLInteractionRepository struct {
+	db Querier
+}
+
+// NewSQLInteractionRepository создаёт новый репозиторий журнала взаимодействий
+func NewSQLInteractionRepository(db Querier) *SQLInteractionRepository {
+	return &SQLInteractionRepository{db: db}
+}
+
+// interactionColumns — столбцы таблицы interactions (кроме id) в порядке scanInteraction
+const interactionColumns = `update_id, update_type, chat_id, user_id, command, text, file_id,
+	handler, latency_ms, outcome, error, created_at`
+
+// Add добавляет запись и заполняет её ID
+func (r *SQLInteractionRepository) Add(ctx context.Context, interaction *domain.Interaction) error {
+	query := `
+		INSERT INTO interactions (` + interactionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	return r.db.QueryRowContext(ctx, query,
+		interaction.UpdateID,
+		interaction.UpdateType,
+		interaction.ChatID,
+		interaction.UserID,
+		interaction.Command,
+		interaction.Text,
+		interaction.FileID,
+		interaction.Handler,
+		interaction.Latency.Milliseconds(),
+		interaction.Outcome,
+		interaction.Error,
+		interaction.CreatedAt.UTC(),
+	).Scan(&interaction.ID)
+}
+
+// Count возвращает количество записей, подходящих под фильтр
+func (r *SQLInteractionRepository) Count(ctx context.Context, filter InteractionFilter) (int, error) {
+	where, args := interactionWhere(filter)
+
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM interactions`+where, args...).Scan(&count)
+	return count, err
+}
+
+// List возвращает записи, подходящие под фильтр, начиная с новых
+func (r *SQLInteractionRepository) List(ctx context.Context, filter InteractionFilter, offset, limit int) ([]domain.Interaction, error) {
+	where, args := interactionWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT id, %s
+		FROM interactions%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, interactionColumns, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interactions []domain.Interaction
+	for rows.Next() {
+		interaction, err := scanInteraction(rows)
+		if err != nil {
+			return nil, err
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, rows.Err()
+}
+
+// TopCommands возвращает limit самых частых команд с since
+func (r *SQLInteractionRepository) TopCommands(ctx context.Context, since time.Time, limit int) ([]CommandStat, error) {
+	query := `
+		SELECT command, COUNT(*), SUM(CASE WHEN outcome = $1 THEN 1 ELSE 0 END)
+		FROM interactions
+		WHERE command <> '' AND created_at >= $2
+		GROUP BY command
+		ORDER BY COUNT(*) DESC, command
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.OutcomeError, since.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []CommandStat
+	for rows.Next() {
+		var stat CommandStat
+		if err := rows.Scan(&stat.Command, &stat.Count, &stat.Errors); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	return stats, rows.Err()
+}
+
+// DeleteBefore удаляет записи старше before
+func (r *SQLInteractionRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM interactions WHERE created_at < $1`, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteByUser удаляет все записи пользователя
+func (r *SQLInteractionRepository) DeleteByUser(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM interactions WHERE user_id = $1`, userID)
+	return err
+}
+
+// interactionWhere формирует условие WHERE для фильтра и его параметры
+func interactionWhere(filter InteractionFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.UserID != 0 {
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.ChatID != 0 {
+		add("chat_id = $%d", filter.ChatID)
+	}
+	if filter.Command != "" {
+		add("command = $%d", filter.Command)
+	}
+	if filter.UpdateType != "" {
+		add("update_type = $%d", filter.UpdateType)
+	}
+	if filter.Outcome != "" {
+		add("outcome = $%d", filter.Outcome)
+	}
+	if !filter.Since.IsZero() {
+		add("created_at >= $%d", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		add("created_at < $%d", filter.Until.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// scanInteraction читает запись из строки результата (id и столбцы interactionColumns)
+func scanInteraction(row rowScanner) (domain.Interaction, error) {
+	var (
+		interaction domain.Interaction
+		latencyMs   int64
+	)
+	err := row.Scan(
+		&interaction.ID,
+		&interaction.UpdateID,
+		&interaction.UpdateType,
+		&interaction.ChatID,
+		&interaction.UserID,
+		&interaction.Command,
+		&interaction.Text,
+		&interaction.FileID,
+		&interaction.Handler,
+		&latencyMs,
+		&interaction.Outcome,
+		&interaction.Error,
+		&interaction.CreatedAt,
+	)
+	interaction.Latency = time.Duration(latencyMs) * time.Millisecond
+	return interaction, err
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLRestrictionRepository хранит ограничения в таблице user_restrictions (PostgreSQL или SQLite)
+type SQLRestrictionRepository struct {
+	db Querier
+}
+
+// NewSQLRestrictionRepository создаёт новый репозиторий ограничений
+func NewSQLRestrictionRepository(db Querier) *SQLRestrictionRepository {
+	return &SQLRestrictionRepository{db: db}
+}
+
+// restrictionColumns — столбцы таблицы user_restrictions в порядке scanRestriction
+const restrictionColumns = `user_id, kind, reason, created_by, created_at, expires_at`
+
+// Get возвращает ограничение пользователя или ErrNotFound
+func (r *SQLRestrictionRepository) Get(ctx context.Context, userID int64) (*domain.Restriction, error) {
+	query := `SELECT ` + restrictionColumns + ` FROM user_restrictions WHERE user_id = $1`
+
+	restriction, err := scanRestriction(r.db.QueryRowContext(ctx, query, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+// Save накладывает ограничение, заменяя прежнее ограничение пользователя
+func (r *SQLRestrictionRepository) Save(ctx context.Context, restriction *domain.Restriction) error {
+	query := `
+		INSERT INTO user_restrictions (` + restrictionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			kind = EXCLUDED.kind,
+			reason = EXCLUDED.reason,
+			created_by = EXCLUDED.created_by,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		restriction.UserID,
+		restriction.Kind,
+		restriction.Reason,
+		restriction.CreatedBy,
+		restriction.CreatedAt.UTC(),
+		nullTime(restriction.ExpiresAt),
+	)
+	return err
+}
+
+// Delete снимает ограничение. Возвращает false, если ограничения не было
+func (r *SQLRestrictionRepository) Delete(ctx context.Context, userID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_restrictions WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// DeleteExpired удаляет ограничения, истёкшие к моменту now
+func (r *SQLRestrictionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_restrictions WHERE expires_at IS NOT NULL AND expires_at <= $1`, now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ListActive возвращает ограничения, действующие в момент now, начиная с новых
+func (r *SQLRestrictionRepository) ListActive(ctx context.Context, now time.Time) ([]domain.Restriction, error) {
+	query := `
+		SELECT ` + restrictionColumns + `
+		FROM user_restrictions
+		WHERE expires_at IS NULL OR expires_at > $1
+		ORDER BY created_at DESC, user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restrictions []domain.Restriction
+	for rows.Next() {
+		restriction, err := scanRestriction(rows)
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions, rows.Err()
+}
+
+// scanRestriction читает ограничение из строки результата (столбцы restrictionColumns)
+func scanRestriction(row rowScanner) (domain.Restriction, error) {
+	var (
+		restriction domain.Restriction
+		expiresAt   sql.NullTime
+	)
+	err := row.Scan(
+		&restriction.UserID,
+		&restriction.Kind,
+		&restriction.Reason,
+		&restriction.CreatedBy,
+		&restriction.CreatedAt,
+		&expiresAt,
+	)
+	restriction.ExpiresAt = expiresAt.Time
+	return restriction, err
+}
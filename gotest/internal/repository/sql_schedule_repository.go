@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLScheduleRepository хранит рассылки по расписанию в таблице scheduled_broadcasts (PostgreSQL или SQLite)
+type SQLScheduleRepository struct {
+	db Querier
+}
+
+// NewSQLScheduleRepository создаёт новый репозиторий рассылок по расписанию
+func NewSQLScheduleRepository(db Querier) *SQLScheduleRepository {
+	return &SQLScheduleRepository{db: db}
+}
+
+// scheduleColumns — столбцы таблицы scheduled_broadcasts (кроме id) в порядке scanSchedule
+const scheduleColumns = `author_id, source_chat_id, source_message_id, forward, audience, recurrence,
+	time_zone, next_run_at, status, last_broadcast_id, created_at, updated_at`
+
+// Create сохраняет запланированную рассылку и заполняет её ID
+func (r *SQLScheduleRepository) Create(ctx context.Context, schedule *domain.ScheduledBroadcast) error {
+	audience, err := json.Marshal(schedule.Audience)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO scheduled_broadcasts (` + scheduleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	return r.db.QueryRowContext(ctx, query,
+		schedule.AuthorID,
+		schedule.SourceChatID,
+		schedule.SourceMessageID,
+		schedule.Forward,
+		string(audience),
+		schedule.Recurrence,
+		schedule.TimeZone,
+		schedule.NextRunAt.UTC(),
+		schedule.Status,
+		schedule.LastBroadcastID,
+		schedule.CreatedAt.UTC(),
+		schedule.UpdatedAt.UTC(),
+	).Scan(&schedule.ID)
+}
+
+// GetByID возвращает запланированную рассылку или ErrNotFound
+func (r *SQLScheduleRepository) GetByID(ctx context.Context, id int64) (*domain.ScheduledBroadcast, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, `+scheduleColumns+` FROM scheduled_broadcasts WHERE id = $1`, id)
+
+	schedule, err := scanSchedule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Update сохраняет изменения запланированной рассылки
+func (r *SQLScheduleRepository) Update(ctx context.Context, schedule *domain.ScheduledBroadcast) error {
+	audience, err := json.Marshal(schedule.Audience)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE scheduled_broadcasts SET
+			source_chat_id = $1,
+			source_message_id = $2,
+			forward = $3,
+			audience = $4,
+			recurrence = $5,
+			time_zone = $6,
+			next_run_at = $7,
+			status = $8,
+			last_broadcast_id = $9,
+			updated_at = $10
+		WHERE id = $11`
+
+	_, err = r.db.ExecContext(ctx, query,
+		schedule.SourceChatID,
+		schedule.SourceMessageID,
+		schedule.Forward,
+		string(audience),
+		schedule.Recurrence,
+		schedule.TimeZone,
+		schedule.NextRunAt.UTC(),
+		schedule.Status,
+		schedule.LastBroadcastID,
+		schedule.UpdatedAt.UTC(),
+		schedule.ID,
+	)
+	return err
+}
+
+// AnonymizeAuthor убирает автора userID из его рассылок и отменяет те, что ещё не отправлены
+func (r *SQLScheduleRepository) AnonymizeAuthor(ctx context.Context, userID int64, now time.Time) error {
+	query := `
+		UPDATE scheduled_broadcasts SET
+			status = $1,
+			updated_at = $2
+		WHERE author_id = $3 AND status = $4`
+
+	if _, err := r.db.ExecContext(ctx, query, domain.ScheduleCancelled, now.UTC(), userID, domain.ScheduleActive); err != nil {
+		return err
+	}
+	_, err := r.db.ExecContext(ctx, `UPDATE scheduled_broadcasts SET author_id = 0 WHERE author_id = $1`, userID)
+	return err
+}
+
+// ListActive возвращает рассылки, которые ещё будут отправлены, начиная с ближайших
+func (r *SQLScheduleRepository) ListActive(ctx context.Context) ([]domain.ScheduledBroadcast, error) {
+	query := `
+		SELECT id, ` + scheduleColumns + `
+		FROM scheduled_broadcasts
+		WHERE status = $1
+		ORDER BY next_run_at, id`
+
+	return r.list(ctx, query, domain.ScheduleActive)
+}
+
+// Due возвращает рассылки, время отправки которых наступило к моменту now, начиная с ранних
+func (r *SQLScheduleRepository) Due(ctx context.Context, now time.Time) ([]domain.ScheduledBroadcast, error) {
+	query := `
+		SELECT id, ` + scheduleColumns + `
+		FROM scheduled_broadcasts
+		WHERE status = $1 AND next_run_at <= $2
+		ORDER BY next_run_at, id`
+
+	return r.list(ctx, query, domain.ScheduleActive, now.UTC())
+}
+
+// list выполняет запрос и читает рассылки из результата
+func (r *SQLScheduleRepository) list(ctx context.Context, query string, args ...any) ([]domain.ScheduledBroadcast, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []domain.ScheduledBroadcast
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// scanSchedule читает запланированную рассылку из строки результата (id и столбцы scheduleColumns)
+func scanSchedule(row rowScanner) (domain.ScheduledBroadcast, error) {
+	var (
+		schedule domain.ScheduledBroadcast
+		audience string
+	)
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.AuthorID,
+		&schedule.SourceChatID,
+		&schedule.SourceMessageID,
+		&schedule.Forward,
+		&audience,
+		&schedule.Recurrence,
+		&schedule.TimeZone,
+		&schedule.NextRunAt,
+		&schedule.Status,
+		&schedule.LastBroadcastID,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return schedule, err
+	}
+
+	err = json.Unmarshal([]byte(audience), &schedule.Audience)
+	return schedule, err
+}
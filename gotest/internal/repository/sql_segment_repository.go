@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLSegmentRepository хранит сегменты в таблице segments (PostgreSQL или SQLite)
+type SQLSegmentRepository struct {
+	db Querier
+}
+
+// NewSQLSegmentRepository создаёт новый репозиторий сегментов
+func NewSQLSegmentRepository(db Querier) *SQLSegmentRepository {
+	return &SQLSegmentRepository{db: db}
+}
+
+// Get возвращает сегмент по имени или ErrNotFound
+func (r *SQLSegmentRepository) Get(ctx context.Context, name string) (*domain.Segment, error) {
+	query := `SELECT name, audience, created_by, updated_at FROM segments WHERE name = $1`
+
+	segment, err := scanSegment(r.db.QueryRowContext(ctx, query, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Save создаёт сегмент или перезаписывает сегмент с тем же именем
+func (r *SQLSegmentRepository) Save(ctx context.Context, segment *domain.Segment) error {
+	audience, err := json.Marshal(segment.Audience)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO segments (name, audience, created_by, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET
+			audience = EXCLUDED.audience,
+			created_by = EXCLUDED.created_by,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = r.db.ExecContext(ctx, query, segment.Name, string(audience), segment.CreatedBy, segment.UpdatedAt.UTC())
+	return err
+}
+
+// List возвращает все сегменты по алфавиту
+func (r *SQLSegmentRepository) List(ctx context.Context) ([]domain.Segment, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT name, audience, created_by, updated_at FROM segments ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var segments []domain.Segment
+	for rows.Next() {
+		segment, err := scanSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, rows.Err()
+}
+
+// Delete удаляет сегмент. Возвращает false, если сегмента не было
+func (r *SQLSegmentRepository) Delete(ctx context.Context, name string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM segments WHERE name = $1`, name)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// AnonymizeAuthor убирает автора userID из сохранённых им сегментов
+func (r *SQLSegmentRepository) AnonymizeAuthor(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE segments SET created_by = 0 WHERE created_by = $1`, userID)
+	return err
+}
+
+// scanSegment читает сегмент из строки результата
+func scanSegment(row rowScanner) (domain.Segment, error) {
+	var (
+		segment  domain.Segment
+		audience string
+	)
+	if err := row.Scan(&segment.Name, &audience, &segment.CreatedBy, &segment.UpdatedAt); err != nil {
+		return segment, err
+	}
+	err := json.Unmarshal([]byte(audience), &segment.Audience)
+	return segment, err
+}
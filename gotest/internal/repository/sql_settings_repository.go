@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLSettingsRepository хранит настройки в таблице user_settings (PostgreSQL или SQLite)
+type SQLSettingsRepository struct {
+	db Querier
+}
+
+// NewSQLSettingsRepository создаёт новый репозиторий настроек
+func NewSQLSettingsRepository(db Querier) *SQLSettingsRepository {
+	return &SQLSettingsRepository{db: db}
+}
+
+// Get возвращает настройки пользователя
+func (r *SQLSettingsRepository) Get(ctx context.Context, userID int64) (*domain.UserSettings, error) {
+	query := `
+		SELECT user_id, language, notifications_enabled, time_zone, quiet_start, quiet_end, updated_at
+		FROM user_settings
+		WHERE user_id = $1`
+
+	settings := &domain.UserSettings{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&settings.UserID,
+		&settings.Language,
+		&settings.NotificationsEnabled,
+		&settings.TimeZone,
+		&settings.QuietStart,
+		&settings.QuietEnd,
+		&settings.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Save создаёт или перезаписывает настройки пользователя
+func (r *SQLSettingsRepository) Save(ctx context.Context, settings *domain.UserSettings) error {
+	query := `
+		INSERT INTO user_settings (user_id, language, notifications_enabled, time_zone, quiet_start, quiet_end, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			language = EXCLUDED.language,
+			notifications_enabled = EXCLUDED.notifications_enabled,
+			time_zone = EXCLUDED.time_zone,
+			quiet_start = EXCLUDED.quiet_start,
+			quiet_end = EXCLUDED.quiet_end,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		settings.UserID,
+		settings.Language,
+		settings.NotificationsEnabled,
+		settings.TimeZone,
+		settings.QuietStart,
+		settings.QuietEnd,
+		settings.UpdatedAt.UTC(),
+	)
+	return err
+}
+
+// Delete удаляет настройки пользователя
+func (r *SQLSettingsRepository) Delete(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_settings WHERE user_id = $1`, userID)
+	return err
+}
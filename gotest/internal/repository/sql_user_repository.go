@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// SQLUserRepository хранит пользователей в таблице users (PostgreSQL или SQLite — запросы общие)
+type SQLUserRepository struct {
+	db Querier
+}
+
+// NewSQLUserRepository создаёт новый репозиторий пользователей
+func NewSQLUserRepository(db Querier) *SQLUserRepository {
+	return &SQLUserRepository{db: db}
+}
+
+// userColumns — столбцы таблицы users в порядке scanUser
+const userColumns = `id, username, first_name, last_name, language_code, is_blocked, first_seen_at, last_seen_at`
+
+// Upsert создаёт пользователя или обновляет его данные
+func (r *SQLUserRepository) Upsert(ctx context.Context, user *domain.User) error {
+	query := `
+		INSERT INTO users (` + userColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			username = EXCLUDED.username,
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			language_code = EXCLUDED.language_code,
+			is_blocked = EXCLUDED.is_blocked,
+			last_seen_at = EXCLUDED.last_seen_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		user.ID,
+		user.Username,
+		user.FirstName,
+		user.LastName,
+		user.LanguageCode,
+		user.IsBlocked,
+		user.FirstSeenAt.UTC(),
+		user.LastSeenAt.UTC(),
+	)
+	return err
+}
+
+// GetByID возвращает пользователя по ID
+func (r *SQLUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetBlocked отмечает, что пользователь заблокировал бота
+func (r *SQLUserRepository) SetBlocked(ctx context.Context, id int64, blocked bool) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET is_blocked = $1 WHERE id = $2`, blocked, id)
+	return err
+}
+
+// Count возвращает количество пользователей
+func (r *SQLUserRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	return count, err
+}
+
+// CountNew возвращает количество пользователей, впервые написавших боту не раньше since
+func (r *SQLUserRepository) CountNew(ctx context.Context, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE first_seen_at >= $1`, since.UTC()).Scan(&count)
+	return count, err
+}
+
+// CountActive возвращает количество пользователей, обращавшихся к боту не раньше since
+func (r *SQLUserRepository) CountActive(ctx context.Context, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE last_seen_at >= $1`, since.UTC()).Scan(&count)
+	return count, err
+}
+
+// CountBlocked возвращает количество пользователей, заблокировавших бота
+func (r *SQLUserRepository) CountBlocked(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE is_blocked = $1`, true).Scan(&count)
+	return count, err
+}
+
+// ReachableIDs возвращает ID пользователей, не заблокировавших бота
+// и подходящих под фильтр, по возрастанию
+func (r *SQLUserRepository) ReachableIDs(ctx context.Context, filter UserFilter) ([]int64, error) {
+	// Язык и уведомления хранятся в настройках, которых у пользователя может не быть
+	conditions := []string{"u.is_blocked = $1"}
+	args := []any{false}
+	add := func(condition string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.Language != "" {
+		language := "COALESCE(NULLIF(s.language, ''), LOWER(u.language_code))"
+		add("("+language+" = $%d OR "+language+" LIKE $%d)", filter.Language, filter.Language+"-%")
+	}
+	if !filter.ActiveSince.IsZero() {
+		add("u.last_seen_at >= $%d", filter.ActiveSince.UTC())
+	}
+	if !filter.JoinedSince.IsZero() {
+		add("u.first_seen_at >= $%d", filter.JoinedSince.UTC())
+	}
+	if filter.Notifications != nil {
+		add("COALESCE(s.notifications_enabled, TRUE) = $%d", *filter.Notifications)
+	}
+
+	query := `
+		SELECT u.id
+		FROM users u
+		LEFT JOIN user_settings s ON s.user_id = u.id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY u.id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// List возвращает пользователей, начиная с недавно активных
+func (r *SQLUserRepository) List(ctx context.Context, offset, limit int) ([]domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		ORDER BY last_seen_at DESC, id
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// Delete удаляет пользователя (настройки удаляются каскадно)
+func (r *SQLUserRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	return err
+}
+
+// rowScanner — *sql.Row или *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser читает пользователя из строки результата (столбцы userColumns)
+func scanUser(row rowScanner) (domain.User, error) {
+	var user domain.User
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.FirstName,
+		&user.LastName,
+		&user.LanguageCode,
+		&user.IsBlocked,
+		&user.FirstSeenAt,
+		&user.LastSeenAt,
+	)
+	return user, err
+}
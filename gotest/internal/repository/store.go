@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Store объединяет репозитории одного хранилища.
+// Обработчики и сервисы получают отдельные репозитории, а какое хранилище
+// за ними стоит (память, PostgreSQL или SQLite), решается при запуске
+type Store struct {
+	Users        UserRepository
+	Settings     SettingsRepository
+	Chats        ChatRepository
+	Audit        AuditRepository
+	Interactions InteractionRepository
+	Broadcasts   BroadcastRepository
+	Segments     SegmentRepository
+	Schedules    ScheduleRepository
+	Restrictions RestrictionRepository
+	Admins       AdminRepository
+
+	db *sql.DB // nil для хранилища в памяти
+}
+
+// NewMemoryStore создаёт хранилище в памяти: данные теряются при перезапуске
+func NewMemoryStore() *Store {
+	settings := NewMemorySettingsRepository()
+	return &Store{
+		Users:        NewMemoryUserRepository(settings),
+		Settings:     settings,
+		Chats:        NewMemoryChatRepository(),
+		Audit:        NewMemoryAuditRepository(),
+		Interactions: NewMemoryInteractionRepository(),
+		Broadcasts:   NewMemoryBroadcastRepository(),
+		Segments:     NewMemorySegmentRepository(),
+		Schedules:    NewMemoryScheduleRepository(),
+		Restrictions: NewMemoryRestrictionRepository(),
+		Admins:       NewMemoryAdminRepository(),
+	}
+}
+
+// NewSQLStore создаёт хранилище поверх открытой БД (PostgreSQL или SQLite).
+// Схема должна быть создана миграциями
+func NewSQLStore(db *sql.DB) *Store {
+	store := newSQLRepositories(db)
+	store.db = db
+	return store
+}
+
+// newSQLRepositories создаёт SQL-репозитории поверх соединения или транзакции
+func newSQLRepositories(db Querier) *Store {
+	return &Store{
+		Users:        NewSQLUserRepository(db),
+		Settings:     NewSQLSettingsRepository(db),
+		Chats:        NewSQLChatRepository(db),
+		Audit:        NewSQLAuditRepository(db),
+		Interactions: NewSQLInteractionRepository(db),
+		Broadcasts:   NewSQLBroadcastRepository(db),
+		Segments:     NewSQLSegmentRepository(db),
+		Schedules:    NewSQLScheduleRepository(db),
+		Restrictions: NewSQLRestrictionRepository(db),
+		Admins:       NewSQLAdminRepository(db),
+	}
+}
+
+// DB возвращает соединение с БД (nil для хранилища в памяти)
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// InTx выполняет fn в одной транзакции: репозитории tx работают внутри неё,
+// и если fn вернёт ошибку, ни одно изменение не сохранится.
+// У хранилища в памяти транзакций нет — fn получает обычные репозитории
+func (s *Store) InTx(ctx context.Context, fn func(tx *Store) error) error {
+	if s.db == nil {
+		return fn(s)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(newSQLRepositories(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close закрывает соединение с БД
+func (s *Store) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"telegram-bot/internal/domain"
+)
+
+// UserFilter отбирает пользователей для рассылок. Пустые поля не ограничивают выбор
+type UserFilter struct {
+	Language      string    // Язык из настроек, а если он не выбран — язык клиента Telegram
+	ActiveSince   time.Time // Обращался к боту не раньше
+	JoinedSince   time.Time // Впервые написал боту не раньше
+	Notifications *bool     // Включены ли уведомления (у пользователя без настроек они включены)
+}
+
+// UserRepository хранит пользователей бота
+type UserRepository interface {
+	// Upsert создаёт пользователя или обновляет его данные.
+	// FirstSeenAt записывается только при создании
+	Upsert(ctx context.Context, user *domain.User) error
+	// GetByID возвращает пользователя или ErrNotFound
+	GetByID(ctx context.Context, id int64) (*domain.User, error)
+	// SetBlocked отмечает, что пользователь заблокировал бота (или разблокировал)
+	SetBlocked(ctx context.Context, id int64, blocked bool) error
+	// Count возвращает количество пользователей
+	Count(ctx context.Context) (int, error)
+	// CountNew возвращает количество пользователей, впервые написавших боту не раньше since
+	CountNew(ctx context.Context, since time.Time) (int, error)
+	// CountActive возвращает количество пользователей, обращавшихся к боту не раньше since
+	CountActive(ctx context.Context, since time.Time) (int, error)
+	// CountBlocked возвращает количество пользователей, заблокировавших бота
+	CountBlocked(ctx context.Context) (int, error)
+	// ReachableIDs возвращает ID пользователей, не заблокировавших бота
+	// и подходящих под фильтр, по возрастанию
+	ReachableIDs(ctx context.Context, filter UserFilter) ([]int64, error)
+	// List возвращает пользователей, начиная с недавно активных
+	List(ctx context.Context, offset, limit int) ([]domain.User, error)
+	// Delete удаляет пользователя. Удаление несуществующего пользователя — не ошибка
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/database"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// testStores возвращает хранилище в памяти и SQLite в памяти со схемой из миграций
+func testStores(t *testing.T) map[string]*repository.Store {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Open(ctx, config.DatabaseConfig{Driver: database.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	return map[string]*repository.Store{
+		"memory": repository.NewMemoryStore(),
+		"sqlite": repository.NewSQLStore(db),
+	}
+}
+
+func TestUserRepositoryUpsert(t *testing.T) {
+	ctx := context.Background()
+	first := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			users := store.Users
+
+			if _, err := users.GetByID(ctx, 42); !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("GetByID() до создания error = %v, want ErrNotFound", err)
+			}
+
+			steps := []struct {
+				name string
+				user domain.User
+				want domain.User
+			}{
+				{
+					name: "создание",
+					user: domain.User{ID: 42, Username: "old", FirstName: "Иван", LanguageCode: "ru", FirstSeenAt: first, LastSeenAt: first},
+					want: domain.User{ID: 42, Username: "old", FirstName: "Иван", LanguageCode: "ru", FirstSeenAt: first, LastSeenAt: first},
+				},
+				{
+					// Обработчик не знает, когда пользователь пришёл впервые, и передаёт текущее время
+					name: "обновление сохраняет первое обращение",
+					user: domain.User{ID: 42, Username: "new", FirstName: "Иван", LastName: "Петров", LanguageCode: "en", FirstSeenAt: later, LastSeenAt: later},
+					want: domain.User{ID: 42, Username: "new", FirstName: "Иван", LastName: "Петров", LanguageCode: "en", FirstSeenAt: first, LastSeenAt: later},
+				},
+				{
+					// Время в другом поясе хранится как тот же момент
+					name: "время не в UTC",
+					user: domain.User{ID: 42, Username: "new", FirstSeenAt: later, LastSeenAt: later.Add(time.Hour).In(time.FixedZone("MSK", 3*60*60))},
+					want: domain.User{ID: 42, Username: "new", FirstSeenAt: first, LastSeenAt: later.Add(time.Hour)},
+				},
+			}
+
+			for _, step := range steps {
+				if err := users.Upsert(ctx, &step.user); err != nil {
+					t.Fatalf("%s: Upsert() error = %v", step.name, err)
+				}
+				got, err := users.GetByID(ctx, step.want.ID)
+				if err != nil {
+					t.Fatalf("%s: GetByID() error = %v", step.name, err)
+				}
+				if !sameUser(*got, step.want) {
+					t.Errorf("%s: GetByID() = %+v, want %+v", step.name, *got, step.want)
+				}
+			}
+
+			if count, err := users.Count(ctx); err != nil || count != 1 {
+				t.Errorf("Count() = %d, %v, want 1", count, err)
+			}
+		})
+	}
+}
+
+func TestUserRepositoryReachable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			users := []domain.User{
+				{ID: 3, LanguageCode: "en", FirstSeenAt: now.AddDate(0, 0, -2), LastSeenAt: now},
+				{ID: 1, LanguageCode: "ru", FirstSeenAt: now.AddDate(0, -1, 0), LastSeenAt: now.AddDate(0, 0, -10)},
+				{ID: 2, LanguageCode: "ru", FirstSeenAt: now.AddDate(0, 0, -1), LastSeenAt: now.AddDate(0, 0, -1)},
+			}
+			for i := range users {
+				if err := store.Users.Upsert(ctx, &users[i]); err != nil {
+					t.Fatalf("Upsert() error = %v", err)
+				}
+			}
+			if err := store.Users.SetBlocked(ctx, 2, true); err != nil {
+				t.Fatalf("SetBlocked() error = %v", err)
+			}
+
+			tests := []struct {
+				name   string
+				filter repository.UserFilter
+				want   []int64
+			}{
+				{"без фильтра, кроме заблокировавших", repository.UserFilter{}, []int64{1, 3}},
+				{"по языку клиента", repository.UserFilter{Language: "ru"}, []int64{1}},
+				{"активные за неделю", repository.UserFilter{ActiveSince: now.AddDate(0, 0, -7)}, []int64{3}},
+				{"новые за неделю", repository.UserFilter{JoinedSince: now.AddDate(0, 0, -7)}, []int64{3}},
+			}
+
+			for _, tt := range tests {
+				got, err := store.Users.ReachableIDs(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("%s: ReachableIDs() error = %v", tt.name, err)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("%s: ReachableIDs() = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+
+			if blocked, err := store.Users.CountBlocked(ctx); err != nil || blocked != 1 {
+				t.Errorf("CountBlocked() = %d, %v, want 1", blocked, err)
+			}
+			if err := store.Users.Delete(ctx, 2); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := store.Users.GetByID(ctx, 2); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("GetByID() после удаления error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+// sameUser сравнивает пользователей, сравнивая время как момент, а не как запись с поясом
+func sameUser(a, b domain.User) bool {
+	if !a.FirstSeenAt.Equal(b.FirstSeenAt) || !a.LastSeenAt.Equal(b.LastSeenAt) {
+		return false
+	}
+	a.FirstSeenAt, a.LastSeenAt = time.Time{}, time.Time{}
+	b.FirstSeenAt, b.LastSeenAt = time.Time{}, time.Time{}
+	return a == b
+}
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// Session — состояние диалога с пользователем между сообщениями
+type Session struct {
+	Flow      string            // Активный сценарий (пусто — сценария нет)
+	Step      string            // Текущий шаг сценария
+	Data      map[string]string // Данные, собранные сценарием
+	UpdatedAt time.Time         // Когда сессия последний раз менялась
+}
+
+// Active сообщает, идёт ли сейчас какой-нибудь сценарий
+func (s Session) Active() bool {
+	return s.Flow != ""
+}
+
+// Store — хранилище сессий в памяти.
+// Сессии, которые не менялись дольше ttl, считаются завершёнными
+type Store struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[int64]Session // Карта: ID пользователя -> сессия
+}
+
+// NewStore создаёт новое хранилище сессий
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:      ttl,
+		sessions: make(map[int64]Session),
+	}
+}
+
+// Get возвращает копию сессии пользователя (пустую, если её нет или она устарела)
+func (s *Store) Get(userID int64) Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, exists := s.sessions[userID]
+	if !exists || s.expired(sess, time.Now()) {
+		delete(s.sessions, userID)
+		return Session{Data: make(map[string]string)}
+	}
+
+	sess.Data = maps.Clone(sess.Data)
+	return sess
+}
+
+// Save сохраняет сессию пользователя
+func (s *Store) Save(userID int64, sess Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.removeExpired(now)
+
+	sess.Data = maps.Clone(sess.Data)
+	sess.UpdatedAt = now
+	s.sessions[userID] = sess
+}
+
+// Start начинает новый сценарий, сбрасывая данные предыдущего
+func (s *Store) Start(userID int64, flow, step string) Session {
+	sess := Session{
+		Flow: flow,
+		Step: step,
+		Data: make(map[string]string),
+	}
+	s.Save(userID, sess)
+	return sess
+}
+
+// Reset завершает сценарий и удаляет сессию пользователя.
+// Возвращает завершённую сессию, чтобы вызывающий мог узнать, что было отменено
+func (s *Store) Reset(userID int64) Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, exists := s.sessions[userID]
+	delete(s.sessions, userID)
+	if !exists || s.expired(sess, time.Now()) {
+		return Session{}
+	}
+	return sess
+}
+
+// expired сообщает, что сессия устарела
+func (s *Store) expired(sess Session, now time.Time) bool {
+	return s.ttl > 0 && now.Sub(sess.UpdatedAt) > s.ttl
+}
+
+// removeExpired удаляет устаревшие сессии (вызывается под блокировкой)
+func (s *Store) removeExpired(now time.Time) {
+	for userID, sess := range s.sessions {
+		if s.expired(sess, now) {
+			delete(s.sessions, userID)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package settings
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// cacheSize — сколько пользователей держать в кэше настроек.
+// Когда кэш заполнен, вытесняются настройки пользователя, обращавшегося к ним раньше всех
+const cacheSize = 10000
+
+// Service даёт обработчикам типизированный доступ к настройкам пользователей.
+// Пока пользователь ничего не менял, возвращаются настройки по умолчанию.
+// Настройки читаются почти для каждого сообщения (язык, часовой пояс), поэтому
+// прочитанные из хранилища настройки недавних пользователей кэшируются; запись обновляет кэш
+type Service struct {
+	repo     repository.SettingsRepository
+	defaults domain.UserSettings
+
+	mu sync.Mutex // Делает чтение-изменение-запись атомарным
+
+	cacheMu sync.Mutex
+	cache   map[int64]*list.Element // Карта: ID пользователя -> элемент recent
+	recent  *list.List              // Настройки в кэше (domain.UserSettings), начиная с недавних
+}
+
+// NewService создаёт сервис настроек. В defaults UserID и UpdatedAt не используются
+func NewService(repo repository.SettingsRepository, defaults domain.UserSettings) *Service {
+	return &Service{
+		repo:     repo,
+		defaults: defaults,
+		cache:    make(map[int64]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// Get возвращает настройки пользователя. При ошибке хранилища — настройки по умолчанию
+func (s *Service) Get(userID int64) domain.UserSettings {
+	settings, err := s.load(userID)
+	if err != nil {
+		log.Printf("Ошибка чтения настроек пользователя %d: %v", userID, err)
+	}
+	return settings
+}
+
+// Update применяет change к настройкам пользователя и сохраняет результат
+func (s *Service) Update(userID int64, change func(settings *domain.UserSettings)) (domain.UserSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings, err := s.load(userID)
+	if err != nil {
+		return settings, err
+	}
+
+	change(&settings)
+	settings.UserID = userID
+	settings.UpdatedAt = time.Now()
+
+	if err := s.repo.Save(context.Background(), &settings); err != nil {
+		s.Forget(userID) // Что сохранилось, неизвестно — перечитаем при следующем запросе
+		return settings, fmt.Errorf("ошибка сохранения настроек пользователя %d: %w", userID, err)
+	}
+
+	s.remember(settings)
+	return settings, nil
+}
+
+// Forget убирает настройки пользователя из кэша. Вызывается, когда настройки
+// изменены в хранилище в обход сервиса (например, удалены вместе с данными пользователя)
+func (s *Service) Forget(userID int64) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if element, exists := s.cache[userID]; exists {
+		s.recent.Remove(element)
+		delete(s.cache, userID)
+	}
+}
+
+// ImportLanguages переносит в настройки языки из JSON-файла прежних версий бота
+// (карта "ID пользователя" -> язык). Язык, уже выбранный в настройках, не меняется.
+// Файл писался, когда бот ещё не хранил пользователей, а настройки ссылаются на users,
+// поэтому для незнакомого пользователя сначала создаётся профиль только с ID:
+// временем первого и последнего обращения считается время изменения файла.
+// Ошибка одного пользователя не останавливает перенос остальных; если такие ошибки были,
+// возвращается ошибка, и при следующем запуске перенос повторяется.
+// Возвращает, сколько языков перенесено; если файла нет, ничего не делает
+func (s *Service) ImportLanguages(path string, users repository.UserRepository) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка чтения %s: %w", path, err)
+	}
+
+	var langs map[string]string
+	if err := json.Unmarshal(data, &langs); err != nil {
+		return 0, fmt.Errorf("ошибка разбора %s: %w", path, err)
+	}
+
+	seenAt := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		seenAt = info.ModTime()
+	}
+
+	imported, failed := 0, 0
+	for key, lang := range langs {
+		userID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || lang == "" {
+			continue
+		}
+
+		changed, err := s.importLanguage(userID, lang, seenAt, users)
+		if err != nil {
+			log.Printf("Ошибка переноса языка пользователя %d: %v", userID, err)
+			failed++
+			continue
+		}
+		if changed {
+			imported++
+		}
+	}
+
+	if failed > 0 {
+		return imported, fmt.Errorf("не перенесено языков: %d из %d", failed, len(langs))
+	}
+	return imported, nil
+}
+
+// importLanguage переносит язык одного пользователя. Возвращает false, если язык уже выбран
+func (s *Service) importLanguage(userID int64, lang string, seenAt time.Time, users repository.UserRepository) (bool, error) {
+	current, err := s.Language(userID)
+	if err != nil || current != "" {
+		return false, err
+	}
+
+	ctx := context.Background()
+	if _, err := users.GetByID(ctx, userID); errors.Is(err, repository.ErrNotFound) {
+		user := domain.User{ID: userID, FirstSeenAt: seenAt.UTC(), LastSeenAt: seenAt.UTC()}
+		if err := users.Upsert(ctx, &user); err != nil {
+			return false, fmt.Errorf("ошибка создания профиля: %w", err)
+		}
+	} else if err != nil {
+		return false, fmt.Errorf("ошибка чтения профиля: %w", err)
+	}
+
+	return true, s.SetLanguage(userID, lang)
+}
+
+// Language возвращает выбранный пользователем язык (пусто — не выбран).
+// Вместе с SetLanguage реализует i18n.LanguageStore
+func (s *Service) Language(userID int64) (string, error) {
+	settings, err := s.load(userID)
+	return settings.Language, err
+}
+
+// SetLanguage сохраняет язык интерфейса
+func (s *Service) SetLanguage(userID int64, lang string) error {
+	_, err := s.Update(userID, func(settings *domain.UserSettings) {
+		settings.Language = lang
+	})
+	return err
+}
+
+// SetNotifications включает или выключает уведомления
+func (s *Service) SetNotifications(userID int64, enabled bool) error {
+	_, err := s.Update(userID, func(settings *domain.UserSettings) {
+		settings.NotificationsEnabled = enabled
+	})
+	return err
+}
+
+// SetTimeZone сохраняет часовой пояс (имя из базы IANA)
+func (s *Service) SetTimeZone(userID int64, name string) error {
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("неизвестный часовой пояс %q: %w", name, err)
+	}
+
+	_, err := s.Update(userID, func(settings *domain.UserSettings) {
+		settings.TimeZone = name
+	})
+	return err
+}
+
+// SetQuietHours сохраняет тихие часы; start == end выключает их
+func (s *Service) SetQuietHours(userID int64, start, end int) error {
+	if start < 0 || start > 23 || end < 0 || end > 23 {
+		return fmt.Errorf("неверные тихие часы: %d–%d", start, end)
+	}
+
+	_, err := s.Update(userID, func(settings *domain.UserSettings) {
+		settings.QuietStart, settings.QuietEnd = start, end
+	})
+	return err
+}
+
+// Location возвращает часовой пояс пользователя
+func (s *Service) Location(userID int64) *time.Location {
+	return s.Get(userID).Location()
+}
+
+// load читает настройки из кэша или хранилища либо подставляет настройки по умолчанию
+func (s *Service) load(userID int64) (domain.UserSettings, error) {
+	if cached, exists := s.cached(userID); exists {
+		return cached, nil
+	}
+
+	settings, err := s.repo.Get(context.Background(), userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		// Ошибку хранилища не кэшируем: при следующем запросе попробуем снова
+		return s.defaultsFor(userID), err
+	}
+
+	loaded := s.defaultsFor(userID)
+	if err == nil {
+		loaded = *settings
+	}
+
+	s.remember(loaded)
+	return loaded, nil
+}
+
+// cached возвращает настройки из кэша и отмечает, что к ним только что обращались
+func (s *Service) cached(userID int64) (domain.UserSettings, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	element, exists := s.cache[userID]
+	if !exists {
+		return domain.UserSettings{}, false
+	}
+	s.recent.MoveToFront(element)
+	return element.Value.(domain.UserSettings), true
+}
+
+// remember кладёт настройки в кэш. Если кэш заполнен, вытесняются самые давние
+func (s *Service) remember(settings domain.UserSettings) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if element, exists := s.cache[settings.UserID]; exists {
+		element.Value = settings
+		s.recent.MoveToFront(element)
+		return
+	}
+
+	s.cache[settings.UserID] = s.recent.PushFront(settings)
+	if s.recent.Len() > cacheSize {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.cache, oldest.Value.(domain.UserSettings).UserID)
+	}
+}
+
+// defaultsFor возвращает настройки по умолчанию для пользователя
+func (s *Service) defaultsFor(userID int64) domain.UserSettings {
+	settings := s.defaults
+	settings.UserID = userID
+	settings.UpdatedAt = time.Time{}
+	return settings
+}
@@ -0,0 +1,163 @@
+package settings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/database"
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// testStores возвращает хранилище в памяти и SQLite в памяти со схемой из миграций
+func testStores(t *testing.T) map[string]*repository.Store {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Open(ctx, config.DatabaseConfig{Driver: database.DriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	return map[string]*repository.Store{
+		"memory": repository.NewMemoryStore(),
+		"sqlite": repository.NewSQLStore(db),
+	}
+}
+
+func TestImportLanguages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	written := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	path := filepath.Join(t.TempDir(), "languages.json")
+	data := `{"1": "en", "2": "en", "3": "ru", "4": "", "bot": "en"}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, written, written); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			service := NewService(store.Settings, domain.UserSettings{NotificationsEnabled: true})
+
+			// Пользователь 2 уже писал боту, пользователь 3 уже выбрал язык
+			for _, user := range []domain.User{
+				{ID: 2, Username: "known", FirstSeenAt: now, LastSeenAt: now},
+				{ID: 3, FirstSeenAt: now, LastSeenAt: now},
+			} {
+				if err := store.Users.Upsert(ctx, &user); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := service.SetLanguage(3, "en"); err != nil {
+				t.Fatal(err)
+			}
+
+			count, err := service.ImportLanguages(path, store.Users)
+			if err != nil {
+				t.Fatalf("ImportLanguages() error = %v", err)
+			}
+			if count != 2 {
+				t.Errorf("ImportLanguages() = %d, want 2", count)
+			}
+
+			tests := []struct {
+				userID int64
+				lang   string
+			}{
+				{1, "en"}, // Профиля не было — создан при переносе
+				{2, "en"},
+				{3, "en"}, // Выбранный язык не меняется
+				{4, ""},
+			}
+			// Читаем хранилище новым сервисом, чтобы не смотреть в кэш
+			fresh := NewService(store.Settings, domain.UserSettings{})
+			for _, tt := range tests {
+				if lang, err := fresh.Language(tt.userID); err != nil || lang != tt.lang {
+					t.Errorf("Language(%d) = %q, %v, want %q", tt.userID, lang, err, tt.lang)
+				}
+			}
+
+			created, err := store.Users.GetByID(ctx, 1)
+			if err != nil {
+				t.Fatalf("профиль пользователя 1 не создан: %v", err)
+			}
+			if !created.FirstSeenAt.Equal(written) || !created.LastSeenAt.Equal(written) {
+				t.Errorf("профиль пользователя 1: %v, %v, want время файла %v", created.FirstSeenAt, created.LastSeenAt, written)
+			}
+			known, err := store.Users.GetByID(ctx, 2)
+			if err != nil || known.Username != "known" || !known.FirstSeenAt.Equal(now) {
+				t.Errorf("профиль пользователя 2 изменён: %+v, %v", known, err)
+			}
+
+			// Повторный перенос ничего не меняет
+			if count, err := service.ImportLanguages(path, store.Users); err != nil || count != 0 {
+				t.Errorf("повторный ImportLanguages() = %d, %v, want 0", count, err)
+			}
+		})
+	}
+}
+
+func TestImportLanguagesMissingFile(t *testing.T) {
+	service := NewService(repository.NewMemorySettingsRepository(), domain.UserSettings{})
+	users := repository.NewMemoryUserRepository(nil)
+
+	count, err := service.ImportLanguages(filepath.Join(t.TempDir(), "languages.json"), users)
+	if err != nil || count != 0 {
+		t.Errorf("ImportLanguages() = %d, %v, want 0, nil", count, err)
+	}
+}
+
+func TestCacheIsBounded(t *testing.T) {
+	service := NewService(repository.NewMemorySettingsRepository(), domain.UserSettings{})
+
+	// Пользователь 1 читается последним перед переполнением и остаётся в кэше
+	for userID := int64(1); userID <= cacheSize; userID++ {
+		service.Get(userID)
+	}
+	service.Get(1)
+	service.Get(cacheSize + 1)
+
+	if got := len(service.cache); got != cacheSize {
+		t.Errorf("len(cache) = %d, want %d", got, cacheSize)
+	}
+	if got := service.recent.Len(); got != cacheSize {
+		t.Errorf("recent.Len() = %d, want %d", got, cacheSize)
+	}
+
+	tests := []struct {
+		userID int64
+		cached bool
+	}{
+		{1, true},
+		{2, false}, // Самый давний — вытеснен
+		{3, true},
+		{cacheSize + 1, true},
+	}
+	for _, tt := range tests {
+		if _, cached := service.cache[tt.userID]; cached != tt.cached {
+			t.Errorf("пользователь %d в кэше = %v, want %v", tt.userID, cached, tt.cached)
+		}
+	}
+
+	service.Forget(1)
+	if _, cached := service.cache[1]; cached || service.recent.Len() != cacheSize-1 {
+		t.Errorf("Forget(1) не убрал настройки из кэша")
+	}
+}
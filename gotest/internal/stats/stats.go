@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"telegram-bot/internal/domain"
+	"telegram-bot/internal/repository"
+)
+
+// Period — период, за который считается статистика
+type Period string
+
+// Периоды, которые можно выбрать кнопками
+const (
+	PeriodToday Period = "today" // С начала текущего дня
+	PeriodWeek  Period = "7d"    // Последние 7 дней
+	PeriodMonth Period = "30d"   // Последние 30 дней
+)
+
+// topCommandsLimit — сколько команд показывать в рейтинге
+const topCommandsLimit = 5
+
+// Since возвращает начало периода; начало дня считается в часовом поясе location
+func (p Period) Since(now time.Time, location *time.Location) (time.Time, error) {
+	switch p {
+	case PeriodToday:
+		local := now.In(location)
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location), nil
+	case PeriodWeek:
+		return now.AddDate(0, 0, -7), nil
+	case PeriodMonth:
+		return now.AddDate(0, 0, -30), nil
+	default:
+		return time.Time{}, fmt.Errorf("неизвестный период %q", p)
+	}
+}
+
+// Report — статистика бота за период
+type Report struct {
+	Period Period    // Период
+	Since  time.Time // Начало периода
+
+	TotalUsers int // Всего пользователей
+	NewUsers   int // Впервые написали за период
+	Blocked    int // Заблокировали бота
+
+	DAU int // Активны за последние сутки
+	WAU int // Активны за последние 7 дней
+	MAU int // Активны за последние 30 дней
+
+	Updates     int                      // Обновлений за период
+	Errors      int                      // Из них обработано с ошибкой
+	TopCommands []repository.CommandStat // Самые частые команды за период
+}
+
+// ErrorRate возвращает долю обновлений с ошибкой (0 — обновлений не было)
+func (r *Report) ErrorRate() float64 {
+	if r.Updates == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Updates)
+}
+
+// Service считает статистику по пользователям и журналу взаимодействий
+type Service struct {
+	users        repository.UserRepository
+	interactions repository.InteractionRepository
+}
+
+// NewService создаёт сервис статистики
+func NewService(users repository.UserRepository, interactions repository.InteractionRepository) *Service {
+	return &Service{
+		users:        users,
+		interactions: interactions,
+	}
+}
+
+// Collect собирает статистику за период
+func (s *Service) Collect(ctx context.Context, period Period, location *time.Location) (*Report, error) {
+	now := time.Now()
+	since, err := period.Since(now, location)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Period: period,
+		Since:  since,
+	}
+
+	// Счётчики собираются одинаково: запрос и поле отчёта, куда записать результат
+	counters := []struct {
+		name  string
+		count func() (int, error)
+		dest  *int
+	}{
+		{"пользователей", func() (int, error) { return s.users.Count(ctx) }, &report.TotalUsers},
+		{"новых пользователей", func() (int, error) { return s.users.CountNew(ctx, since) }, &report.NewUsers},
+		{"заблокировавших", func() (int, error) { return s.users.CountBlocked(ctx) }, &report.Blocked},
+		{"DAU", func() (int, error) { return s.users.CountActive(ctx, now.AddDate(0, 0, -1)) }, &report.DAU},
+		{"WAU", func() (int, error) { return s.users.CountActive(ctx, now.AddDate(0, 0, -7)) }, &report.WAU},
+		{"MAU", func() (int, error) { return s.users.CountActive(ctx, now.AddDate(0, 0, -30)) }, &report.MAU},
+		{"обновлений", func() (int, error) {
+			return s.interactions.Count(ctx, repository.InteractionFilter{Since: since})
+		}, &report.Updates},
+		{"ошибок", func() (int, error) {
+			return s.interactions.Count(ctx, repository.InteractionFilter{Since: since, Outcome: domain.OutcomeError})
+		}, &report.Errors},
+	}
+	for _, counter := range counters {
+		value, err := counter.count()
+		if err != nil {
+			return nil, fmt.Errorf("ошибка подсчёта %s: %w", counter.name, err)
+		}
+		*counter.dest = value
+	}
+
+	report.TopCommands, err = s.interactions.TopCommands(ctx, since, topCommandsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка подсчёта команд: %w", err)
+	}
+
+	return report, nil
+}